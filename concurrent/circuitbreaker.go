@@ -0,0 +1,358 @@
+package concurrent
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jwmajors81/golang-commons-lang/timeutil"
+)
+
+// Returned by Execute when the circuit breaker does not allow the call
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// The state of a CircuitBreaker
+type State int
+
+const (
+	// Calls are allowed and failures are counted
+	Closed State = iota
+	// Calls are rejected until the open duration has passed
+	Open
+	// A limited number of trial calls are allowed to probe whether the
+	// protected service has recovered
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+// Called when a CircuitBreaker changes from one state to another
+type StateListener func(from State, to State)
+
+// Configures a CircuitBreaker
+type CircuitBreakerOption func(*CircuitBreaker)
+
+// Sets how long the circuit breaker stays open before allowing trial calls.
+// Defaults to the interval of the circuit breaker.
+func WithOpenDuration(d time.Duration) CircuitBreakerOption {
+	return func(cb *CircuitBreaker) {
+		cb.openDuration = d
+	}
+}
+
+// Sets the number of successful trial calls needed to close the circuit
+// breaker again.  Defaults to 1.
+func WithTrialCalls(n int) CircuitBreakerOption {
+	return func(cb *CircuitBreaker) {
+		if n > 0 {
+			cb.trialCalls = n
+		}
+	}
+}
+
+// Sets the clock used to measure the interval and open duration, useful for testing
+func WithBreakerClock(clock timeutil.Clock) CircuitBreakerOption {
+	return func(cb *CircuitBreaker) {
+		cb.clock = clock
+	}
+}
+
+// Adds a listener that is notified of state changes
+func WithStateListener(listener StateListener) CircuitBreakerOption {
+	return func(cb *CircuitBreaker) {
+		cb.listeners = append(cb.listeners, listener)
+	}
+}
+
+// Sets which errors returned to Execute count as events.  By default every
+// error except context.Canceled counts.  Errors that do not count are not
+// successful trial calls either.
+func WithFailurePredicate(isFailure func(err error) bool) CircuitBreakerOption {
+	return func(cb *CircuitBreaker) {
+		cb.isFailure = isFailure
+	}
+}
+
+type event struct {
+	at    time.Time
+	count int
+}
+
+type stateChange struct {
+	from State
+	to   State
+}
+
+// Protects calls to an unreliable service, modeled on Apache Commons
+// EventCountCircuitBreaker.  The breaker opens when more than threshold
+// events, usually failures, happen within a sliding interval.  Once the open
+// duration has passed it becomes half-open and allows a number of trial
+// calls: if they all succeed it closes, if any fails it opens again.  A
+// CircuitBreaker is safe for use by multiple goroutines.
+type CircuitBreaker struct {
+	threshold    int
+	interval     time.Duration
+	openDuration time.Duration
+	trialCalls   int
+	clock        timeutil.Clock
+	isFailure    func(err error) bool
+
+	mu        sync.Mutex
+	listeners []StateListener
+	state     State
+	events    []event
+	openedAt  time.Time
+	trials    int
+	successes int
+	probe     uint64
+	changes   []stateChange
+}
+
+// Creates a closed circuit breaker that opens when more than threshold
+// events happen within interval
+func NewCircuitBreaker(threshold int, interval time.Duration, opts ...CircuitBreakerOption) *CircuitBreaker {
+	cb := &CircuitBreaker{
+		threshold:    threshold,
+		interval:     interval,
+		openDuration: interval,
+		trialCalls:   1,
+		clock:        timeutil.SystemClock,
+		isFailure: func(err error) bool {
+			return !errors.Is(err, context.Canceled)
+		},
+	}
+	for _, opt := range opts {
+		opt(cb)
+	}
+	return cb
+}
+
+// Adds a listener that is notified of state changes.  Listeners are called
+// synchronously after the change and must not block.
+func (cb *CircuitBreaker) AddListener(listener StateListener) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.listeners = append(cb.listeners[:len(cb.listeners):len(cb.listeners)], listener)
+}
+
+// Returns the current state, moving from open to half-open when the open
+// duration has passed
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	state := cb.refresh(cb.clock.Now())
+	cb.unlock()
+	return state
+}
+
+// Returns whether the circuit breaker is closed
+func (cb *CircuitBreaker) IsClosed() bool {
+	return cb.State() == Closed
+}
+
+// Returns whether the circuit breaker is open
+func (cb *CircuitBreaker) IsOpen() bool {
+	return cb.State() == Open
+}
+
+// Records one event and returns whether the circuit breaker is still closed
+func (cb *CircuitBreaker) Increment() bool {
+	return cb.IncrementBy(1)
+}
+
+// Records a number of events and returns whether the circuit breaker is
+// still closed.  Events recorded while half-open open the breaker again.
+func (cb *CircuitBreaker) IncrementBy(count int) bool {
+	cb.mu.Lock()
+	now := cb.clock.Now()
+	cb.refresh(now)
+	switch cb.state {
+	case Closed:
+		cb.record(now, count)
+	case HalfOpen:
+		cb.transition(Open, now)
+	}
+	closed := cb.state == Closed
+	cb.unlock()
+	return closed
+}
+
+// Forces the circuit breaker open, it becomes half-open once the open duration has passed
+func (cb *CircuitBreaker) Open() {
+	cb.force(Open)
+}
+
+// Forces the circuit breaker closed and forgets the recorded events
+func (cb *CircuitBreaker) Close() {
+	cb.force(Closed)
+}
+
+func (cb *CircuitBreaker) force(state State) {
+	cb.mu.Lock()
+	cb.transition(state, cb.clock.Now())
+	cb.unlock()
+}
+
+// The outcome of a call made by Execute
+type outcome int
+
+const (
+	succeeded outcome = iota
+	failed
+	// The call returned an error that is not a failure, which neither counts
+	// as an event nor as a successful trial call
+	neutral
+)
+
+// Calls fn when the circuit breaker allows it and records the outcome.
+// Returns ErrCircuitOpen without calling fn when the breaker is open or all
+// trial calls of a half-open breaker are in progress, and the context error
+// when the context is already done.  A panic in fn is recorded as a failure
+// before it continues.
+func (cb *CircuitBreaker) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	trial, probe, err := cb.acquire()
+	if err != nil {
+		return err
+	}
+
+	result := failed
+	defer func() {
+		cb.complete(trial, probe, result)
+	}()
+
+	err = fn(ctx)
+	switch {
+	case err == nil:
+		result = succeeded
+	case !cb.isFailure(err):
+		result = neutral
+	}
+	return err
+}
+
+// Reserves a call.  Trial calls of a half-open breaker return the probe they
+// belong to so that a late result cannot affect a later half-open period.
+func (cb *CircuitBreaker) acquire() (bool, uint64, error) {
+	cb.mu.Lock()
+	trial := false
+	var err error
+	switch cb.refresh(cb.clock.Now()) {
+	case Open:
+		err = ErrCircuitOpen
+	case HalfOpen:
+		if cb.trials >= cb.trialCalls {
+			err = ErrCircuitOpen
+		} else {
+			cb.trials++
+			trial = true
+		}
+	}
+	probe := cb.probe
+	cb.unlock()
+	return trial, probe, err
+}
+
+// Records the outcome of a call.  A neutral trial call gives its place back
+// so that another trial call can decide whether the breaker closes.
+func (cb *CircuitBreaker) complete(trial bool, probe uint64, result outcome) {
+	cb.mu.Lock()
+	now := cb.clock.Now()
+	cb.refresh(now)
+
+	current := trial && cb.state == HalfOpen && probe == cb.probe
+	switch {
+	case current && result == failed:
+		cb.transition(Open, now)
+	case current && result == neutral:
+		cb.trials--
+	case current:
+		cb.successes++
+		if cb.successes >= cb.trialCalls {
+			cb.transition(Closed, now)
+		}
+	case cb.state == Closed && result == failed:
+		cb.record(now, 1)
+	}
+
+	cb.unlock()
+}
+
+// Releases the lock and then tells the listeners about the state changes
+// made while it was held, so that listeners may call the circuit breaker
+func (cb *CircuitBreaker) unlock() {
+	changes := cb.changes
+	cb.changes = nil
+	listeners := cb.listeners
+	cb.mu.Unlock()
+
+	for _, change := range changes {
+		for _, listener := range listeners {
+			listener(change.from, change.to)
+		}
+	}
+}
+
+// Records events while closed, opening the breaker when the threshold is
+// exceeded.  Must be called with the lock held.
+func (cb *CircuitBreaker) record(now time.Time, count int) {
+	cb.events = append(cb.events, event{at: now, count: count})
+	cb.prune(now)
+
+	total := 0
+	for _, e := range cb.events {
+		total += e.count
+	}
+	if total > cb.threshold {
+		cb.transition(Open, now)
+	}
+}
+
+// Drops the events that are outside the interval.  Must be called with the lock held.
+func (cb *CircuitBreaker) prune(now time.Time) {
+	cutoff := now.Add(-cb.interval)
+	i := 0
+	for i < len(cb.events) && !cb.events[i].at.After(cutoff) {
+		i++
+	}
+	cb.events = cb.events[i:]
+}
+
+// Moves an open breaker to half-open once the open duration has passed and
+// returns the state.  Must be called with the lock held.
+func (cb *CircuitBreaker) refresh(now time.Time) State {
+	if cb.state == Open && now.Sub(cb.openedAt) >= cb.openDuration {
+		cb.transition(HalfOpen, now)
+	}
+	return cb.state
+}
+
+// Changes the state and resets the bookkeeping of the new state.  Must be
+// called with the lock held.
+func (cb *CircuitBreaker) transition(to State, now time.Time) {
+	from := cb.state
+	switch to {
+	case Open:
+		cb.openedAt = now
+	case HalfOpen:
+		cb.trials, cb.successes = 0, 0
+		cb.probe++
+	case Closed:
+		cb.events = nil
+	}
+	if from != to {
+		cb.state = to
+		cb.changes = append(cb.changes, stateChange{from: from, to: to})
+	}
+}
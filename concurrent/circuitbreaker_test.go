@@ -0,0 +1,253 @@
+package concurrent
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jwmajors81/golang-commons-lang/timeutil"
+	"github.com/stretchr/testify/assert"
+)
+
+var errUnavailable = errors.New("service unavailable")
+
+func failing(ctx context.Context) error {
+	return errUnavailable
+}
+
+func succeeding(ctx context.Context) error {
+	return nil
+}
+
+type recordingListener struct {
+	mu      sync.Mutex
+	changes []string
+}
+
+func (l *recordingListener) listen(from State, to State) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.changes = append(l.changes, from.String()+" -> "+to.String())
+}
+
+func TestStateString(t *testing.T) {
+	assert.Equal(t, "closed", Closed.String())
+	assert.Equal(t, "open", Open.String())
+	assert.Equal(t, "half-open", HalfOpen.String())
+}
+
+func TestCircuitBreakerOpensWhenThresholdExceeded(t *testing.T) {
+	clock := timeutil.NewFakeClock(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	cb := NewCircuitBreaker(2, time.Minute, WithBreakerClock(clock))
+
+	assert.True(t, cb.Increment())
+	assert.True(t, cb.Increment())
+	assert.True(t, cb.IsClosed())
+	assert.False(t, cb.Increment())
+	assert.True(t, cb.IsOpen())
+}
+
+func TestCircuitBreakerSlidingWindow(t *testing.T) {
+	clock := timeutil.NewFakeClock(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	cb := NewCircuitBreaker(2, time.Minute, WithBreakerClock(clock))
+
+	cb.Increment()
+	clock.Advance(40 * time.Second)
+	cb.Increment()
+	clock.Advance(30 * time.Second)
+
+	// the first event is now outside the window
+	assert.True(t, cb.Increment())
+	assert.False(t, cb.IncrementBy(2))
+}
+
+func TestCircuitBreakerHalfOpenProbing(t *testing.T) {
+	clock := timeutil.NewFakeClock(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	listener := &recordingListener{}
+	cb := NewCircuitBreaker(1, time.Minute,
+		WithBreakerClock(clock),
+		WithOpenDuration(30*time.Second),
+		WithTrialCalls(2),
+		WithStateListener(listener.listen))
+	ctx := context.Background()
+
+	assert.Equal(t, errUnavailable, cb.Execute(ctx, failing))
+	assert.Equal(t, errUnavailable, cb.Execute(ctx, failing))
+	assert.Equal(t, Open, cb.State())
+
+	called := false
+	err := cb.Execute(ctx, func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.False(t, called)
+
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, HalfOpen, cb.State())
+
+	// a failed trial opens the breaker again for another open duration
+	assert.Equal(t, errUnavailable, cb.Execute(ctx, failing))
+	assert.Equal(t, Open, cb.State())
+	clock.Advance(29 * time.Second)
+	assert.Equal(t, Open, cb.State())
+	clock.Advance(time.Second)
+
+	assert.Nil(t, cb.Execute(ctx, succeeding))
+	assert.Equal(t, HalfOpen, cb.State())
+	assert.Nil(t, cb.Execute(ctx, succeeding))
+	assert.Equal(t, Closed, cb.State())
+
+	assert.Equal(t, []string{
+		"closed -> open",
+		"open -> half-open",
+		"half-open -> open",
+		"open -> half-open",
+		"half-open -> closed",
+	}, listener.changes)
+}
+
+func TestCircuitBreakerLimitsConcurrentTrials(t *testing.T) {
+	clock := timeutil.NewFakeClock(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	cb := NewCircuitBreaker(0, time.Minute, WithBreakerClock(clock))
+	ctx := context.Background()
+
+	cb.Open()
+	clock.Advance(time.Minute)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- cb.Execute(ctx, func(ctx context.Context) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	assert.ErrorIs(t, cb.Execute(ctx, succeeding), ErrCircuitOpen)
+	close(release)
+	assert.Nil(t, <-done)
+	assert.True(t, cb.IsClosed())
+}
+
+func TestCircuitBreakerIgnoresLateTrialResults(t *testing.T) {
+	clock := timeutil.NewFakeClock(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	cb := NewCircuitBreaker(0, time.Minute, WithBreakerClock(clock), WithTrialCalls(2))
+	ctx := context.Background()
+
+	cb.Open()
+	clock.Advance(time.Minute)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- cb.Execute(ctx, func(ctx context.Context) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	// the other trial fails and a new half-open period starts
+	assert.Equal(t, errUnavailable, cb.Execute(ctx, failing))
+	clock.Advance(time.Minute)
+	assert.Equal(t, HalfOpen, cb.State())
+
+	close(release)
+	assert.Nil(t, <-done)
+	assert.Equal(t, HalfOpen, cb.State())
+}
+
+func TestCircuitBreakerFailurePredicate(t *testing.T) {
+	errNotFound := errors.New("not found")
+	cb := NewCircuitBreaker(0, time.Minute, WithFailurePredicate(func(err error) bool {
+		return !errors.Is(err, errNotFound)
+	}))
+	ctx := context.Background()
+
+	assert.Equal(t, errNotFound, cb.Execute(ctx, func(ctx context.Context) error { return errNotFound }))
+	assert.True(t, cb.IsClosed())
+	assert.Equal(t, errUnavailable, cb.Execute(ctx, failing))
+	assert.True(t, cb.IsOpen())
+}
+
+func TestCircuitBreakerContext(t *testing.T) {
+	cb := NewCircuitBreaker(0, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, cb.Execute(ctx, succeeding), context.Canceled)
+
+	// cancellation is not a failure of the protected service
+	err := cb.Execute(context.Background(), func(ctx context.Context) error { return context.Canceled })
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.True(t, cb.IsClosed())
+}
+
+func TestCircuitBreakerNeutralTrialResults(t *testing.T) {
+	clock := timeutil.NewFakeClock(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	cb := NewCircuitBreaker(0, time.Minute, WithBreakerClock(clock))
+	ctx := context.Background()
+
+	cb.Open()
+	clock.Advance(time.Minute)
+
+	// a cancelled trial neither closes the breaker nor keeps its place
+	err := cb.Execute(ctx, func(ctx context.Context) error { return context.Canceled })
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, HalfOpen, cb.State())
+
+	assert.Nil(t, cb.Execute(ctx, succeeding))
+	assert.True(t, cb.IsClosed())
+}
+
+func TestCircuitBreakerPanics(t *testing.T) {
+	clock := timeutil.NewFakeClock(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	cb := NewCircuitBreaker(0, time.Minute, WithBreakerClock(clock))
+	ctx := context.Background()
+	panicking := func(ctx context.Context) error {
+		panic("boom")
+	}
+
+	assert.PanicsWithValue(t, "boom", func() { _ = cb.Execute(ctx, panicking) })
+	assert.True(t, cb.IsOpen())
+
+	clock.Advance(time.Minute)
+	assert.PanicsWithValue(t, "boom", func() { _ = cb.Execute(ctx, panicking) })
+	assert.True(t, cb.IsOpen())
+
+	clock.Advance(time.Minute)
+	assert.Nil(t, cb.Execute(ctx, succeeding))
+	assert.True(t, cb.IsClosed())
+}
+
+func TestCircuitBreakerForcedStates(t *testing.T) {
+	listener := &recordingListener{}
+	cb := NewCircuitBreaker(5, time.Minute)
+	cb.AddListener(listener.listen)
+
+	cb.Open()
+	cb.Open()
+	assert.True(t, cb.IsOpen())
+	cb.Close()
+	assert.True(t, cb.IsClosed())
+	assert.Equal(t, []string{"closed -> open", "open -> closed"}, listener.changes)
+}
+
+func TestCircuitBreakerListenerMayCallBreaker(t *testing.T) {
+	var seen State
+	var cb *CircuitBreaker
+	cb = NewCircuitBreaker(0, time.Minute, WithStateListener(func(from State, to State) {
+		seen = cb.State()
+	}))
+
+	cb.Increment()
+	assert.Equal(t, Open, seen)
+}
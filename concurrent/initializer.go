@@ -0,0 +1,269 @@
+package concurrent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// Returned when the result of an initializer is requested before it was started
+	ErrNotStarted = errors.New("initializer has not been started")
+	// Returned when an initializer is changed after it was started
+	ErrAlreadyStarted = errors.New("initializer has already been started")
+)
+
+// Creates a value the first time it is needed, similar to Apache Commons
+// LazyInitializer and AtomicSafeInitializer.  The initialize function runs at
+// most once at a time.  When it fails the error is returned and the next call
+// to Get tries again; once it succeeds the value is kept.  A Lazy is safe for
+// use by multiple goroutines.
+type Lazy[T any] struct {
+	initialize func() (T, error)
+	done       uint32
+	mu         sync.Mutex
+	value      T
+}
+
+// Creates a Lazy that uses the function provided to create its value
+func NewLazy[T any](initialize func() (T, error)) *Lazy[T] {
+	return &Lazy[T]{initialize: initialize}
+}
+
+// Returns the value, creating it if it has not been created yet
+func (l *Lazy[T]) Get() (T, error) {
+	if atomic.LoadUint32(&l.done) == 1 {
+		return l.value, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.done == 0 {
+		value, err := l.initialize()
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		l.value = value
+		atomic.StoreUint32(&l.done, 1)
+	}
+	return l.value, nil
+}
+
+// Returns whether the value has been created
+func (l *Lazy[T]) IsInitialized() bool {
+	return atomic.LoadUint32(&l.done) == 1
+}
+
+// Creates a value in a separate goroutine, similar to Apache Commons
+// BackgroundInitializer.  Start begins the work and Get waits for the
+// result.  A BackgroundInitializer is safe for use by multiple goroutines.
+type BackgroundInitializer[T any] struct {
+	initialize func(ctx context.Context) (T, error)
+	mu         sync.Mutex
+	started    bool
+	done       chan struct{}
+	value      T
+	err        error
+}
+
+// Creates a BackgroundInitializer that uses the function provided to create its value
+func NewBackgroundInitializer[T any](initialize func(ctx context.Context) (T, error)) *BackgroundInitializer[T] {
+	return &BackgroundInitializer[T]{initialize: initialize, done: make(chan struct{})}
+}
+
+// Starts creating the value in a new goroutine.  The context is passed to the
+// initialize function so that it can be cancelled.  Returns false when the
+// initializer was already started.
+func (b *BackgroundInitializer[T]) Start(ctx context.Context) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.started {
+		return false
+	}
+	b.started = true
+
+	go func() {
+		defer close(b.done)
+		b.value, b.err = b.initialize(ctx)
+	}()
+	return true
+}
+
+// Returns whether Start has been called
+func (b *BackgroundInitializer[T]) IsStarted() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.started
+}
+
+// Returns a channel that is closed once the initialize function has returned
+func (b *BackgroundInitializer[T]) Done() <-chan struct{} {
+	return b.done
+}
+
+// Waits for the value to be created and returns it.  Returns the context's
+// error if it is done before the value is ready, and ErrNotStarted if Start
+// was never called.
+func (b *BackgroundInitializer[T]) Get(ctx context.Context) (T, error) {
+	var zero T
+	if !b.IsStarted() {
+		return zero, ErrNotStarted
+	}
+
+	select {
+	case <-b.done:
+		return b.value, b.err
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// Runs several named initializers in parallel, similar to Apache Commons
+// MultiBackgroundInitializer.  A MultiBackgroundInitializer is safe for use
+// by multiple goroutines.
+type MultiBackgroundInitializer struct {
+	mu           sync.Mutex
+	initializers map[string]*BackgroundInitializer[any]
+	started      bool
+}
+
+// Creates an empty MultiBackgroundInitializer
+func NewMultiBackgroundInitializer() *MultiBackgroundInitializer {
+	return &MultiBackgroundInitializer{initializers: map[string]*BackgroundInitializer[any]{}}
+}
+
+// Adds a named initializer.  Names must be unique and initializers cannot be
+// added once Start has been called.
+func (m *MultiBackgroundInitializer) Add(name string, initialize func(ctx context.Context) (any, error)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.started {
+		return ErrAlreadyStarted
+	}
+	if _, exists := m.initializers[name]; exists {
+		return fmt.Errorf("an initializer named %q has already been added", name)
+	}
+	m.initializers[name] = NewBackgroundInitializer(initialize)
+	return nil
+}
+
+// Starts all initializers, each in its own goroutine.  Returns false when
+// Start was already called.
+func (m *MultiBackgroundInitializer) Start(ctx context.Context) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.started {
+		return false
+	}
+	m.started = true
+	for _, initializer := range m.initializers {
+		initializer.Start(ctx)
+	}
+	return true
+}
+
+// Waits for every initializer to finish and returns their results.  Returns
+// the context's error if it is done first, and ErrNotStarted if Start was
+// never called.
+func (m *MultiBackgroundInitializer) Get(ctx context.Context) (*MultiResults, error) {
+	m.mu.Lock()
+	started := m.started
+	initializers := make(map[string]*BackgroundInitializer[any], len(m.initializers))
+	for name, initializer := range m.initializers {
+		initializers[name] = initializer
+	}
+	m.mu.Unlock()
+
+	if !started {
+		return nil, ErrNotStarted
+	}
+
+	results := &MultiResults{values: map[string]any{}, errors: map[string]error{}}
+	for name, initializer := range initializers {
+		select {
+		case <-initializer.Done():
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if initializer.err != nil {
+			results.errors[name] = initializer.err
+		} else {
+			results.values[name] = initializer.value
+		}
+	}
+	return results, nil
+}
+
+// The results of a MultiBackgroundInitializer keyed by initializer name
+type MultiResults struct {
+	values map[string]any
+	errors map[string]error
+}
+
+// Returns the value created by the named initializer.  An error is returned
+// when the initializer failed or no initializer has the name.
+func (r *MultiResults) Value(name string) (any, error) {
+	if err, failed := r.errors[name]; failed {
+		return nil, err
+	}
+	value, ok := r.values[name]
+	if !ok {
+		return nil, fmt.Errorf("no initializer named %q", name)
+	}
+	return value, nil
+}
+
+// Returns the error of the named initializer, or nil if it succeeded
+func (r *MultiResults) Err(name string) error {
+	return r.errors[name]
+}
+
+// Returns whether every initializer succeeded
+func (r *MultiResults) IsSuccessful() bool {
+	return len(r.errors) == 0
+}
+
+// Returns the names of all initializers in sorted order
+func (r *MultiResults) Names() []string {
+	names := make([]string, 0, len(r.values)+len(r.errors))
+	for name := range r.values {
+		names = append(names, name)
+	}
+	for name := range r.errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Returns the names of the initializers that failed in sorted order
+func (r *MultiResults) FailedNames() []string {
+	names := make([]string, 0, len(r.errors))
+	for name := range r.errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Returns the value of the named initializer converted to T
+func ResultAs[T any](r *MultiResults, name string) (T, error) {
+	var zero T
+	value, err := r.Value(name)
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := value.(T)
+	if !ok {
+		return zero, fmt.Errorf("the value of %q is a %T, not a %T", name, value, zero)
+	}
+	return typed, nil
+}
@@ -0,0 +1,171 @@
+package concurrent
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLazy(t *testing.T) {
+	var calls int32
+	lazy := NewLazy(func() (string, error) {
+		atomic.AddInt32(&calls, 1)
+		return "value", nil
+	})
+
+	assert.False(t, lazy.IsInitialized())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := lazy.Get()
+			assert.Nil(t, err)
+			assert.Equal(t, "value", value)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.True(t, lazy.IsInitialized())
+}
+
+func TestLazyRetriesAfterError(t *testing.T) {
+	failures := 2
+	lazy := NewLazy(func() (int, error) {
+		if failures > 0 {
+			failures--
+			return 0, errors.New("not yet")
+		}
+		return 42, nil
+	})
+
+	_, err := lazy.Get()
+	assert.EqualError(t, err, "not yet")
+	_, err = lazy.Get()
+	assert.EqualError(t, err, "not yet")
+	assert.False(t, lazy.IsInitialized())
+
+	value, err := lazy.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 42, value)
+
+	value, err = lazy.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 42, value)
+}
+
+func TestBackgroundInitializer(t *testing.T) {
+	release := make(chan struct{})
+	initializer := NewBackgroundInitializer(func(ctx context.Context) (int, error) {
+		<-release
+		return 7, nil
+	})
+
+	_, err := initializer.Get(context.Background())
+	assert.ErrorIs(t, err, ErrNotStarted)
+
+	assert.True(t, initializer.Start(context.Background()))
+	assert.False(t, initializer.Start(context.Background()))
+	assert.True(t, initializer.IsStarted())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = initializer.Get(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	value, err := initializer.Get(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 7, value)
+}
+
+func TestBackgroundInitializerCancellation(t *testing.T) {
+	initializer := NewBackgroundInitializer(func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	initializer.Start(ctx)
+	cancel()
+
+	<-initializer.Done()
+	_, err := initializer.Get(context.Background())
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestMultiBackgroundInitializer(t *testing.T) {
+	multi := NewMultiBackgroundInitializer()
+	assert.Nil(t, multi.Add("config", func(ctx context.Context) (any, error) {
+		return map[string]string{"env": "test"}, nil
+	}))
+	assert.Nil(t, multi.Add("database", func(ctx context.Context) (any, error) {
+		return nil, errors.New("connection refused")
+	}))
+	assert.Nil(t, multi.Add("cache", func(ctx context.Context) (any, error) {
+		time.Sleep(5 * time.Millisecond)
+		return 128, nil
+	}))
+	assert.EqualError(t, multi.Add("cache", nil), `an initializer named "cache" has already been added`)
+
+	_, err := multi.Get(context.Background())
+	assert.ErrorIs(t, err, ErrNotStarted)
+
+	assert.True(t, multi.Start(context.Background()))
+	assert.False(t, multi.Start(context.Background()))
+	assert.ErrorIs(t, multi.Add("late", nil), ErrAlreadyStarted)
+
+	results, err := multi.Get(context.Background())
+	assert.Nil(t, err)
+	assert.False(t, results.IsSuccessful())
+	assert.Equal(t, []string{"cache", "config", "database"}, results.Names())
+	assert.Equal(t, []string{"database"}, results.FailedNames())
+
+	value, err := results.Value("cache")
+	assert.Nil(t, err)
+	assert.Equal(t, 128, value)
+	assert.Nil(t, results.Err("cache"))
+
+	_, err = results.Value("database")
+	assert.EqualError(t, err, "connection refused")
+	assert.EqualError(t, results.Err("database"), "connection refused")
+
+	_, err = results.Value("missing")
+	assert.EqualError(t, err, `no initializer named "missing"`)
+
+	config, err := ResultAs[map[string]string](results, "config")
+	assert.Nil(t, err)
+	assert.Equal(t, "test", config["env"])
+
+	_, err = ResultAs[string](results, "cache")
+	assert.EqualError(t, err, `the value of "cache" is a int, not a string`)
+}
+
+func TestMultiBackgroundInitializerRunsInParallel(t *testing.T) {
+	multi := NewMultiBackgroundInitializer()
+	var running int32
+	barrier := make(chan struct{})
+	for _, name := range []string{"a", "b", "c"} {
+		_ = multi.Add(name, func(ctx context.Context) (any, error) {
+			if atomic.AddInt32(&running, 1) == 3 {
+				close(barrier)
+			}
+			<-barrier
+			return true, nil
+		})
+	}
+
+	multi.Start(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	results, err := multi.Get(ctx)
+	assert.Nil(t, err)
+	assert.True(t, results.IsSuccessful())
+}
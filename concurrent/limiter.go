@@ -0,0 +1,276 @@
+package concurrent
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/jwmajors81/golang-commons-lang/timeutil"
+)
+
+// Limits the rate at which an operation may be performed.  Limiters are safe
+// for use by multiple goroutines.
+type Limiter interface {
+	// Blocks until a permit is available or the context is done, in which
+	// case the context error is returned
+	Acquire(ctx context.Context) error
+	// Takes a permit if one is available without waiting
+	TryAcquire() bool
+	// Returns statistics about the permits acquired
+	Stats() LimiterStats
+}
+
+// Statistics about the permits acquired from a Limiter.  Permits are counted
+// in consecutive periods of the limiter's duration starting when it was
+// created.
+type LimiterStats struct {
+	// Permits acquired in the current period
+	Acquired int
+	// Permits acquired in the last completed period
+	LastPeriod int
+	// Average permits acquired per completed period
+	AveragePerPeriod float64
+	// Permits that can be acquired now without waiting, or -1 when there is no limit
+	Available int
+}
+
+// Configures a Limiter
+type LimiterOption func(*limiterConfig)
+
+type limiterConfig struct {
+	clock timeutil.Clock
+}
+
+// Sets the clock used to measure periods and wait for permits, useful for testing
+func WithLimiterClock(clock timeutil.Clock) LimiterOption {
+	return func(c *limiterConfig) {
+		c.clock = clock
+	}
+}
+
+func newLimiterConfig(opts []LimiterOption) limiterConfig {
+	config := limiterConfig{clock: timeutil.SystemClock}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+// Panics with the message when an argument is invalid, which is a mistake in
+// the calling code rather than an error to handle
+func require(valid bool, format string, args ...any) {
+	if !valid {
+		panic(fmt.Sprintf(format, args...))
+	}
+}
+
+// Calls reserve until it takes a permit, waiting in between for the duration
+// it returns
+func acquire(ctx context.Context, clock timeutil.Clock, reserve func() (time.Duration, bool)) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		wait, ok := reserve()
+		if ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clock.After(wait):
+		}
+	}
+}
+
+// Counts acquisitions in consecutive periods for LimiterStats
+type periodCounter struct {
+	start   time.Time
+	period  time.Duration
+	current int
+	last    int
+	total   int64
+	periods int64
+}
+
+func newPeriodCounter(now time.Time, period time.Duration) periodCounter {
+	return periodCounter{start: now, period: period}
+}
+
+// Moves to the period containing now
+func (c *periodCounter) roll(now time.Time) {
+	elapsed := int64(now.Sub(c.start) / c.period)
+	if elapsed <= 0 {
+		return
+	}
+	if elapsed == 1 {
+		c.last = c.current
+	} else {
+		c.last = 0
+	}
+	c.total += int64(c.current)
+	c.periods += elapsed
+	c.current = 0
+	c.start = c.start.Add(time.Duration(elapsed) * c.period)
+}
+
+// Returns the start of the period following the current one
+func (c *periodCounter) end() time.Time {
+	return c.start.Add(c.period)
+}
+
+func (c *periodCounter) stats(available int) LimiterStats {
+	stats := LimiterStats{Acquired: c.current, LastPeriod: c.last, Available: available}
+	if c.periods > 0 {
+		stats.AveragePerPeriod = float64(c.total) / float64(c.periods)
+	}
+	return stats
+}
+
+// A Limiter that allows bursts of up to burst permits and refills at rate
+// permits per period
+type TokenBucket struct {
+	rate   int
+	per    time.Duration
+	burst  int
+	clock  timeutil.Clock
+	mu     sync.Mutex
+	tokens float64
+	filled time.Time
+	count  periodCounter
+}
+
+// Creates a full token bucket that refills at rate permits per period and
+// holds at most burst permits.  Panics when rate, per or burst is not
+// positive.
+func NewTokenBucket(rate int, per time.Duration, burst int, opts ...LimiterOption) *TokenBucket {
+	require(rate > 0, "NewTokenBucket: the rate must be positive, got %d", rate)
+	require(per > 0, "NewTokenBucket: the period must be positive, got %v", per)
+	require(burst > 0, "NewTokenBucket: the burst must be positive, got %d", burst)
+	config := newLimiterConfig(opts)
+	now := config.clock.Now()
+	return &TokenBucket{
+		rate:   rate,
+		per:    per,
+		burst:  burst,
+		clock:  config.clock,
+		tokens: float64(burst),
+		filled: now,
+		count:  newPeriodCounter(now, per),
+	}
+}
+
+// Blocks until a permit is available or the context is done
+func (b *TokenBucket) Acquire(ctx context.Context) error {
+	return acquire(ctx, b.clock, b.reserve)
+}
+
+// Takes a permit if one is available without waiting
+func (b *TokenBucket) TryAcquire() bool {
+	_, ok := b.reserve()
+	return ok
+}
+
+// Returns statistics about the permits acquired
+func (b *TokenBucket) Stats() LimiterStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(b.clock.Now())
+	return b.count.stats(int(b.tokens))
+}
+
+// Takes a token or returns how long it will take for one to be added
+func (b *TokenBucket) reserve() (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(b.clock.Now())
+	if b.tokens >= 1 {
+		b.tokens--
+		b.count.current++
+		return 0, true
+	}
+	return time.Duration(math.Ceil((1 - b.tokens) * float64(b.per) / float64(b.rate))), false
+}
+
+// Adds the tokens earned since the last refill.  Must be called with the lock held.
+func (b *TokenBucket) refill(now time.Time) {
+	b.count.roll(now)
+	if elapsed := now.Sub(b.filled); elapsed > 0 {
+		b.tokens = math.Min(float64(b.burst), b.tokens+float64(elapsed)*float64(b.rate)/float64(b.per))
+		b.filled = now
+	}
+}
+
+// A Limiter that allows at most limit permits within any window of time
+type SlidingWindowLimiter struct {
+	limit  int
+	window time.Duration
+	clock  timeutil.Clock
+	mu     sync.Mutex
+	times  []time.Time
+	count  periodCounter
+}
+
+// Creates a limiter that allows at most limit permits within any window of
+// time.  Panics when limit or window is not positive.
+func NewSlidingWindowLimiter(limit int, window time.Duration, opts ...LimiterOption) *SlidingWindowLimiter {
+	require(limit > 0, "NewSlidingWindowLimiter: the limit must be positive, got %d", limit)
+	require(window > 0, "NewSlidingWindowLimiter: the window must be positive, got %v", window)
+	config := newLimiterConfig(opts)
+	return &SlidingWindowLimiter{
+		limit:  limit,
+		window: window,
+		clock:  config.clock,
+		count:  newPeriodCounter(config.clock.Now(), window),
+	}
+}
+
+// Blocks until a permit is available or the context is done
+func (l *SlidingWindowLimiter) Acquire(ctx context.Context) error {
+	return acquire(ctx, l.clock, l.reserve)
+}
+
+// Takes a permit if one is available without waiting
+func (l *SlidingWindowLimiter) TryAcquire() bool {
+	_, ok := l.reserve()
+	return ok
+}
+
+// Returns statistics about the permits acquired
+func (l *SlidingWindowLimiter) Stats() LimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.clock.Now()
+	l.prune(now)
+	l.count.roll(now)
+	return l.count.stats(l.limit - len(l.times))
+}
+
+// Takes a permit or returns how long it will be until the oldest permit
+// leaves the window
+func (l *SlidingWindowLimiter) reserve() (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	l.prune(now)
+	l.count.roll(now)
+	if len(l.times) < l.limit {
+		l.times = append(l.times, now)
+		l.count.current++
+		return 0, true
+	}
+	return l.times[0].Add(l.window).Sub(now), false
+}
+
+// Forgets the permits that have left the window.  Must be called with the lock held.
+func (l *SlidingWindowLimiter) prune(now time.Time) {
+	i := 0
+	for i < len(l.times) && !l.times[i].Add(l.window).After(now) {
+		i++
+	}
+	l.times = l.times[i:]
+}
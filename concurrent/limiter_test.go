@@ -0,0 +1,184 @@
+package concurrent
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jwmajors81/golang-commons-lang/timeutil"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ Limiter = (*TimedSemaphore)(nil)
+	_ Limiter = (*TokenBucket)(nil)
+	_ Limiter = (*SlidingWindowLimiter)(nil)
+)
+
+func TestLimitersAllowBurst(t *testing.T) {
+	var tests = map[string]struct {
+		create func(clock timeutil.Clock) Limiter
+	}{
+		"timed semaphore": {create: func(clock timeutil.Clock) Limiter {
+			return NewTimedSemaphore(time.Second, 5, WithLimiterClock(clock))
+		}},
+		"token bucket": {create: func(clock timeutil.Clock) Limiter {
+			return NewTokenBucket(5, time.Second, 5, WithLimiterClock(clock))
+		}},
+		"sliding window": {create: func(clock timeutil.Clock) Limiter {
+			return NewSlidingWindowLimiter(5, time.Second, WithLimiterClock(clock))
+		}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			clock := timeutil.NewFakeClock(limiterEpoch)
+			limiter := test.create(clock)
+
+			for i := 0; i < 5; i++ {
+				assert.True(t, limiter.TryAcquire())
+			}
+			assert.False(t, limiter.TryAcquire())
+			assert.Equal(t, LimiterStats{Acquired: 5}, limiter.Stats())
+
+			clock.Advance(time.Second)
+			stats := limiter.Stats()
+			assert.Equal(t, 5, stats.LastPeriod)
+			assert.Equal(t, 5.0, stats.AveragePerPeriod)
+			assert.Equal(t, 5, stats.Available)
+		})
+	}
+}
+
+func TestTokenBucketRefill(t *testing.T) {
+	clock := timeutil.NewFakeClock(limiterEpoch)
+	bucket := NewTokenBucket(10, time.Second, 2, WithLimiterClock(clock))
+
+	assert.True(t, bucket.TryAcquire())
+	assert.True(t, bucket.TryAcquire())
+	assert.False(t, bucket.TryAcquire())
+
+	clock.Advance(50 * time.Millisecond)
+	assert.False(t, bucket.TryAcquire())
+	clock.Advance(50 * time.Millisecond)
+	assert.True(t, bucket.TryAcquire())
+
+	// the bucket never holds more than the burst
+	clock.Advance(time.Hour)
+	assert.Equal(t, 2, bucket.Stats().Available)
+}
+
+func TestTokenBucketAcquireWaitsForToken(t *testing.T) {
+	clock := timeutil.NewFakeClock(limiterEpoch)
+	bucket := NewTokenBucket(4, time.Second, 1, WithLimiterClock(clock))
+	assert.Nil(t, bucket.Acquire(context.Background()))
+
+	done := make(chan error)
+	go func() {
+		done <- bucket.Acquire(context.Background())
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(249 * time.Millisecond)
+	assert.Len(t, done, 0)
+	clock.Advance(time.Millisecond)
+	assert.Nil(t, <-done)
+}
+
+func TestSlidingWindowLimiter(t *testing.T) {
+	clock := timeutil.NewFakeClock(limiterEpoch)
+	limiter := NewSlidingWindowLimiter(2, time.Minute, WithLimiterClock(clock))
+
+	assert.True(t, limiter.TryAcquire())
+	clock.Advance(40 * time.Second)
+	assert.True(t, limiter.TryAcquire())
+	assert.False(t, limiter.TryAcquire())
+
+	// a fixed period would have reset at one minute, the window has not
+	clock.Advance(19 * time.Second)
+	assert.False(t, limiter.TryAcquire())
+	clock.Advance(time.Second)
+	assert.True(t, limiter.TryAcquire())
+	assert.False(t, limiter.TryAcquire())
+
+	done := make(chan error)
+	go func() {
+		done <- limiter.Acquire(context.Background())
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(40 * time.Second)
+	assert.Nil(t, <-done)
+}
+
+func TestLimiterAcquireConcurrently(t *testing.T) {
+	clock := timeutil.NewFakeClock(limiterEpoch)
+	limiter := NewSlidingWindowLimiter(3, time.Second, WithLimiterClock(clock))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, limiter.Acquire(context.Background()))
+		}()
+	}
+
+	clock.BlockUntil(3)
+	clock.Advance(time.Second)
+	wg.Wait()
+	assert.Equal(t, 3, limiter.Stats().Acquired)
+	assert.Equal(t, 3, limiter.Stats().LastPeriod)
+}
+
+func TestLimiterAcquireWithDoneContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, NewTokenBucket(1, time.Second, 1).Acquire(ctx), context.Canceled)
+}
+
+func TestLimiterInvalidArguments(t *testing.T) {
+	var tests = map[string]struct {
+		create          func()
+		expectedMessage string
+	}{
+		"token bucket rate": {
+			create:          func() { NewTokenBucket(0, time.Second, 1) },
+			expectedMessage: "NewTokenBucket: the rate must be positive, got 0",
+		},
+		"token bucket period": {
+			create:          func() { NewTokenBucket(1, 0, 1) },
+			expectedMessage: "NewTokenBucket: the period must be positive, got 0s",
+		},
+		"token bucket burst": {
+			create:          func() { NewTokenBucket(1, time.Second, -1) },
+			expectedMessage: "NewTokenBucket: the burst must be positive, got -1",
+		},
+		"sliding window limit": {
+			create:          func() { NewSlidingWindowLimiter(0, time.Second) },
+			expectedMessage: "NewSlidingWindowLimiter: the limit must be positive, got 0",
+		},
+		"sliding window window": {
+			create:          func() { NewSlidingWindowLimiter(1, -time.Second) },
+			expectedMessage: "NewSlidingWindowLimiter: the window must be positive, got -1s",
+		},
+		"timed semaphore period": {
+			create:          func() { NewTimedSemaphore(0, 1) },
+			expectedMessage: "NewTimedSemaphore: the period must be positive, got 0s",
+		},
+		"timed semaphore limit": {
+			create:          func() { NewTimedSemaphore(time.Second, -1) },
+			expectedMessage: "NewTimedSemaphore: the limit must not be negative, got -1",
+		},
+		"timed semaphore set limit": {
+			create:          func() { NewTimedSemaphore(time.Second, NoLimit).SetLimit(-1) },
+			expectedMessage: "SetLimit: the limit must not be negative, got -1",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.PanicsWithValue(t, test.expectedMessage, test.create)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package concurrent
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jwmajors81/golang-commons-lang/timeutil"
+)
+
+// Passed to NewTimedSemaphore or SetLimit to allow any number of permits
+const NoLimit = 0
+
+// A Limiter that allows a fixed number of permits per period, similar to
+// Apache Commons TimedSemaphore.  Unlike a TokenBucket the permits of a
+// period are not spread out: all of them may be taken at the start of the
+// period, after which callers wait for the next period to begin.
+type TimedSemaphore struct {
+	clock timeutil.Clock
+	mu    sync.Mutex
+	limit int
+	count periodCounter
+}
+
+// Creates a semaphore that allows limit permits in each period.  A limit of
+// NoLimit allows any number of permits.  Panics when period is not positive
+// or limit is negative.
+func NewTimedSemaphore(period time.Duration, limit int, opts ...LimiterOption) *TimedSemaphore {
+	require(period > 0, "NewTimedSemaphore: the period must be positive, got %v", period)
+	require(limit >= NoLimit, "NewTimedSemaphore: the limit must not be negative, got %d", limit)
+	config := newLimiterConfig(opts)
+	return &TimedSemaphore{
+		clock: config.clock,
+		limit: limit,
+		count: newPeriodCounter(config.clock.Now(), period),
+	}
+}
+
+// Returns the number of permits allowed in each period
+func (s *TimedSemaphore) Limit() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.limit
+}
+
+// Changes the number of permits allowed in each period, taking effect
+// immediately for the current period.  Panics when limit is negative.
+func (s *TimedSemaphore) SetLimit(limit int) {
+	require(limit >= NoLimit, "SetLimit: the limit must not be negative, got %d", limit)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limit = limit
+}
+
+// Returns the length of a period
+func (s *TimedSemaphore) Period() time.Duration {
+	return s.count.period
+}
+
+// Blocks until a permit is available or the context is done
+func (s *TimedSemaphore) Acquire(ctx context.Context) error {
+	return acquire(ctx, s.clock, s.reserve)
+}
+
+// Takes a permit if one is available in the current period without waiting
+func (s *TimedSemaphore) TryAcquire() bool {
+	_, ok := s.reserve()
+	return ok
+}
+
+// Returns statistics about the permits acquired
+func (s *TimedSemaphore) Stats() LimiterStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.count.roll(s.clock.Now())
+	if s.limit <= NoLimit {
+		return s.count.stats(-1)
+	}
+	available := s.limit - s.count.current
+	if available < 0 {
+		available = 0
+	}
+	return s.count.stats(available)
+}
+
+// Takes a permit or returns how long it is until the next period starts
+func (s *TimedSemaphore) reserve() (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	s.count.roll(now)
+	if s.limit <= NoLimit || s.count.current < s.limit {
+		s.count.current++
+		return 0, true
+	}
+	return s.count.end().Sub(now), false
+}
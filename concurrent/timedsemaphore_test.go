@@ -0,0 +1,82 @@
+package concurrent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jwmajors81/golang-commons-lang/timeutil"
+	"github.com/stretchr/testify/assert"
+)
+
+var limiterEpoch = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestTimedSemaphoreTryAcquire(t *testing.T) {
+	clock := timeutil.NewFakeClock(limiterEpoch)
+	semaphore := NewTimedSemaphore(time.Second, 3, WithLimiterClock(clock))
+
+	assert.True(t, semaphore.TryAcquire())
+	assert.True(t, semaphore.TryAcquire())
+	assert.True(t, semaphore.TryAcquire())
+	assert.False(t, semaphore.TryAcquire())
+	assert.Equal(t, LimiterStats{Acquired: 3, Available: 0}, semaphore.Stats())
+
+	clock.Advance(time.Second)
+	assert.Equal(t, LimiterStats{Acquired: 0, LastPeriod: 3, AveragePerPeriod: 3, Available: 3}, semaphore.Stats())
+	assert.True(t, semaphore.TryAcquire())
+
+	// two periods pass, the second of them without any permits
+	clock.Advance(2 * time.Second)
+	assert.Equal(t, LimiterStats{Acquired: 0, LastPeriod: 0, AveragePerPeriod: 4.0 / 3, Available: 3}, semaphore.Stats())
+}
+
+func TestTimedSemaphoreAcquireWaitsForNextPeriod(t *testing.T) {
+	clock := timeutil.NewFakeClock(limiterEpoch)
+	semaphore := NewTimedSemaphore(time.Minute, 1, WithLimiterClock(clock))
+	assert.Nil(t, semaphore.Acquire(context.Background()))
+
+	clock.Advance(20 * time.Second)
+	done := make(chan error)
+	go func() {
+		done <- semaphore.Acquire(context.Background())
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(39 * time.Second)
+	assert.Len(t, done, 0)
+	clock.Advance(time.Second)
+	assert.Nil(t, <-done)
+	assert.Equal(t, 1, semaphore.Stats().Acquired)
+}
+
+func TestTimedSemaphoreAcquireCancelled(t *testing.T) {
+	clock := timeutil.NewFakeClock(limiterEpoch)
+	semaphore := NewTimedSemaphore(time.Minute, 1, WithLimiterClock(clock))
+	semaphore.TryAcquire()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- semaphore.Acquire(ctx)
+	}()
+
+	clock.BlockUntil(1)
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestTimedSemaphoreLimit(t *testing.T) {
+	clock := timeutil.NewFakeClock(limiterEpoch)
+	semaphore := NewTimedSemaphore(time.Second, NoLimit, WithLimiterClock(clock))
+	assert.Equal(t, time.Second, semaphore.Period())
+
+	for i := 0; i < 100; i++ {
+		assert.True(t, semaphore.TryAcquire())
+	}
+	assert.Equal(t, -1, semaphore.Stats().Available)
+
+	semaphore.SetLimit(50)
+	assert.Equal(t, 50, semaphore.Limit())
+	assert.False(t, semaphore.TryAcquire())
+	assert.Equal(t, 0, semaphore.Stats().Available)
+}
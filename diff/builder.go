@@ -0,0 +1,45 @@
+package diff
+
+import "github.com/jwmajors81/golang-commons-lang/equality"
+
+// Builds a DiffResult one field at a time, similar to Apache Commons
+// DiffBuilder.
+//
+//	result := NewDiffBuilder().
+//		Append("name", a.Name, b.Name).
+//		Append("replicas", a.Replicas, b.Replicas).
+//		Build()
+type DiffBuilder struct {
+	diffs []Difference
+	opts  []equality.Option
+}
+
+// Creates a builder that compares appended values using equality.DeepEqual
+// with the options provided
+func NewDiffBuilder(opts ...equality.Option) *DiffBuilder {
+	return &DiffBuilder{opts: opts}
+}
+
+// Records a difference for the field when the left and right values are not equal
+func (b *DiffBuilder) Append(field string, left, right any) *DiffBuilder {
+	if !equality.DeepEqual(left, right, b.opts...) {
+		b.diffs = append(b.diffs, Difference{Path: field, Type: Modified, Left: left, Right: right})
+	}
+	return b
+}
+
+// Records all differences of a nested result with their paths prefixed by the field name
+func (b *DiffBuilder) AppendResult(field string, result DiffResult) *DiffBuilder {
+	for _, d := range result.Diffs {
+		d.Path = joinPath(field, d.Path)
+		b.diffs = append(b.diffs, d)
+	}
+	return b
+}
+
+// Returns the differences recorded so far
+func (b *DiffBuilder) Build() DiffResult {
+	diffs := make([]Difference, len(b.diffs))
+	copy(diffs, b.diffs)
+	return DiffResult{Diffs: diffs}
+}
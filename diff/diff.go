@@ -0,0 +1,106 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Describes how a value changed between the left and right side of a comparison
+type ChangeType string
+
+const (
+	Added    ChangeType = "added"
+	Removed  ChangeType = "removed"
+	Modified ChangeType = "modified"
+)
+
+// A single difference between two values.  Path identifies where the values
+// differ, such as `spec.containers[2].image`.  Left is nil for added values
+// and Right is nil for removed values.
+type Difference struct {
+	Path  string     `json:"path"`
+	Type  ChangeType `json:"type"`
+	Left  any        `json:"left,omitempty"`
+	Right any        `json:"right,omitempty"`
+}
+
+// Returns the difference as "path: left -> right"
+func (d Difference) String() string {
+	switch d.Type {
+	case Added:
+		return fmt.Sprintf("%s: added %s", d.Path, formatValue(d.Right))
+	case Removed:
+		return fmt.Sprintf("%s: removed %s", d.Path, formatValue(d.Left))
+	}
+	return fmt.Sprintf("%s: %s -> %s", d.Path, formatValue(d.Left), formatValue(d.Right))
+}
+
+func formatValue(value any) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// The list of differences found between two values
+type DiffResult struct {
+	Diffs []Difference
+}
+
+// Returns whether any differences were found
+func (r DiffResult) HasDiffs() bool {
+	return len(r.Diffs) > 0
+}
+
+// Returns the number of differences found
+func (r DiffResult) Len() int {
+	return len(r.Diffs)
+}
+
+// Returns the difference recorded for the path provided, if there is one
+func (r DiffResult) Get(path string) (Difference, bool) {
+	for _, d := range r.Diffs {
+		if d.Path == path {
+			return d, true
+		}
+	}
+	return Difference{}, false
+}
+
+// Returns the paths of all differences
+func (r DiffResult) Paths() []string {
+	paths := make([]string, len(r.Diffs))
+	for i, d := range r.Diffs {
+		paths[i] = d.Path
+	}
+	return paths
+}
+
+// Returns a human readable report with one difference per line
+func (r DiffResult) String() string {
+	lines := make([]string, len(r.Diffs))
+	for i, d := range r.Diffs {
+		lines[i] = d.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Marshals the result as a JSON array of differences
+func (r DiffResult) MarshalJSON() ([]byte, error) {
+	if r.Diffs == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(r.Diffs)
+}
+
+// Joins a parent path and a field name using '.' as the separator
+func joinPath(parent string, field string) string {
+	if parent == "" {
+		return field
+	}
+	if field == "" || strings.HasPrefix(field, "[") {
+		return parent + field
+	}
+	return parent + "." + field
+}
@@ -0,0 +1,264 @@
+package diff
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Container struct {
+	Name  string `json:"name"`
+	Image string `json:"image"`
+}
+
+type Spec struct {
+	Replicas   int               `json:"replicas"`
+	Containers []Container       `json:"containers"`
+	Labels     map[string]string `json:"labels,omitempty"`
+}
+
+type Deployment struct {
+	Name     string    `json:"name"`
+	Spec     *Spec     `json:"spec"`
+	Created  time.Time `json:"created" diff:"-"`
+	Revision int
+	internal string
+}
+
+func newDeployment() Deployment {
+	return Deployment{
+		Name: "web",
+		Spec: &Spec{
+			Replicas: 2,
+			Containers: []Container{
+				{Name: "app", Image: "app:1"},
+				{Name: "proxy", Image: "nginx:1"},
+				{Name: "sidecar", Image: "log:1"},
+			},
+			Labels: map[string]string{"app": "web", "tier": "frontend"},
+		},
+		Created:  time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		Revision: 1,
+		internal: "a",
+	}
+}
+
+func TestDiffIdentical(t *testing.T) {
+	result := Diff(newDeployment(), newDeployment())
+	assert.False(t, result.HasDiffs())
+	assert.Equal(t, "", result.String())
+}
+
+func TestDiffFieldPaths(t *testing.T) {
+	left, right := newDeployment(), newDeployment()
+	right.Spec.Replicas = 3
+	right.Spec.Containers[2].Image = "log:2"
+	right.Spec.Labels["tier"] = "backend"
+	right.Spec.Labels["team"] = "ops"
+	delete(right.Spec.Labels, "app")
+	right.Revision = 2
+	right.internal = "b"
+
+	result := Diff(left, right)
+
+	assert.Equal(t, []string{
+		"spec.replicas",
+		"spec.containers[2].image",
+		"spec.labels[app]",
+		"spec.labels[team]",
+		"spec.labels[tier]",
+		"Revision",
+	}, result.Paths())
+
+	d, ok := result.Get("spec.containers[2].image")
+	assert.True(t, ok)
+	assert.Equal(t, Difference{Path: "spec.containers[2].image", Type: Modified, Left: "log:1", Right: "log:2"}, d)
+
+	d, _ = result.Get("spec.labels[app]")
+	assert.Equal(t, Removed, d.Type)
+	assert.Equal(t, "web", d.Left)
+	assert.Nil(t, d.Right)
+
+	d, _ = result.Get("spec.labels[team]")
+	assert.Equal(t, Added, d.Type)
+	assert.Equal(t, "ops", d.Right)
+}
+
+func TestDiffTimesUseEqual(t *testing.T) {
+	left, right := newDeployment(), newDeployment()
+	right.Created = left.Created.In(time.FixedZone("EST", -5*60*60))
+	assert.False(t, Diff(left, right).HasDiffs())
+
+	right.Created = right.Created.Add(time.Second)
+	assert.Equal(t, []string{"created"}, Diff(left, right).Paths())
+	assert.False(t, Diff(left, right, IgnoreTag("diff")).HasDiffs())
+}
+
+func TestDiffIgnorePaths(t *testing.T) {
+	left, right := newDeployment(), newDeployment()
+	right.Spec.Containers[0].Image = "app:2"
+	right.Spec.Containers[1].Image = "nginx:2"
+	right.Spec.Replicas = 5
+
+	result := Diff(left, right, IgnorePaths("spec.replicas", "spec.containers[*].image"))
+	assert.False(t, result.HasDiffs())
+
+	result = Diff(left, right, IgnorePaths("spec.containers[0].image"))
+	assert.Equal(t, []string{"spec.replicas", "spec.containers[1].image"}, result.Paths())
+}
+
+func TestDiffNilPointers(t *testing.T) {
+	left, right := newDeployment(), newDeployment()
+	right.Spec = nil
+
+	result := Diff(left, right)
+	assert.Equal(t, []string{"spec"}, result.Paths())
+	assert.Nil(t, result.Diffs[0].Right.(*Spec))
+}
+
+func TestDiffNilEqualsEmpty(t *testing.T) {
+	left, right := newDeployment(), newDeployment()
+	left.Spec.Labels = nil
+	right.Spec.Labels = map[string]string{}
+
+	assert.Equal(t, []string{"spec.labels"}, Diff(left, right).Paths())
+	assert.False(t, Diff(left, right, NilEqualsEmpty()).HasDiffs())
+}
+
+func TestDiffSliceByIndex(t *testing.T) {
+	left := []string{"a", "b", "c"}
+	right := []string{"x", "a", "b", "c"}
+
+	result := Diff(left, right)
+	assert.Equal(t, []string{"[0]", "[1]", "[2]", "[3]"}, result.Paths())
+	assert.Equal(t, Added, result.Diffs[3].Type)
+}
+
+func TestDiffSliceLCS(t *testing.T) {
+	var tests = map[string]struct {
+		left     []string
+		right    []string
+		expected []Difference
+	}{
+		"insert at start": {
+			left: []string{"a", "b", "c"}, right: []string{"x", "a", "b", "c"},
+			expected: []Difference{{Path: "[0]", Type: Added, Right: "x"}},
+		},
+		"remove in middle": {
+			left: []string{"a", "b", "c"}, right: []string{"a", "c"},
+			expected: []Difference{{Path: "[1]", Type: Removed, Left: "b"}},
+		},
+		"replace in middle": {
+			left: []string{"a", "b", "c"}, right: []string{"a", "x", "c"},
+			expected: []Difference{{Path: "[1]", Type: Modified, Left: "b", Right: "x"}},
+		},
+		"identical": {
+			left: []string{"a", "b"}, right: []string{"a", "b"},
+		},
+		"empty left": {
+			left: nil, right: []string{"a"},
+			expected: []Difference{{Path: "", Type: Modified, Left: []string(nil), Right: []string{"a"}}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := Diff(test.left, test.right, WithSliceAlignment(AlignByLCS))
+			assert.Equal(t, test.expected, result.Diffs)
+		})
+	}
+}
+
+func TestDiffSliceLCSNested(t *testing.T) {
+	left, right := newDeployment(), newDeployment()
+	right.Spec.Containers = append([]Container{{Name: "init", Image: "busybox"}}, right.Spec.Containers...)
+	right.Spec.Containers[2].Image = "nginx:2"
+
+	result := Diff(left, right, WithSliceAlignment(AlignByLCS))
+	assert.Equal(t, []string{"spec.containers[0]", "spec.containers[2].image"}, result.Paths())
+}
+
+func TestDiffCycles(t *testing.T) {
+	type node struct {
+		Value int
+		Next  *node
+	}
+	left := &node{Value: 1}
+	left.Next = left
+	right := &node{Value: 2}
+	right.Next = right
+
+	assert.Equal(t, []string{"Value"}, Diff(left, right).Paths())
+}
+
+func TestDiffSharedValues(t *testing.T) {
+	type leaf struct {
+		Value int
+	}
+	type pair struct {
+		A, B *leaf
+	}
+	left, right := &leaf{Value: 1}, &leaf{Value: 2}
+
+	assert.Equal(t, []string{"A.Value", "B.Value"}, Diff(pair{A: left, B: left}, pair{A: right, B: right}).Paths())
+}
+
+func TestDiffMapKeysThatPrintAlike(t *testing.T) {
+	left := map[any]int{1: 1, "1": 2}
+	right := map[any]int{1: 1, "1": 3}
+
+	result := Diff(left, right)
+	assert.Equal(t, []Difference{{Path: "[1]", Type: Modified, Left: 2, Right: 3}}, result.Diffs)
+
+	type key struct {
+		Name string
+	}
+	a, b := &key{Name: "x"}, &key{Name: "x"}
+	result = Diff(map[*key]int{a: 1, b: 2}, map[*key]int{a: 1, b: 5})
+	assert.Equal(t, []Difference{{Path: "[&{x}]", Type: Modified, Left: 2, Right: 5}}, result.Diffs)
+
+	result = Diff(map[any]int{1: 1}, map[any]int{"1": 1})
+	assert.ElementsMatch(t, []Difference{
+		{Path: "[1]", Type: Removed, Left: 1},
+		{Path: "[1]", Type: Added, Right: 1},
+	}, result.Diffs)
+}
+
+func TestDiffResultFormatting(t *testing.T) {
+	left, right := newDeployment(), newDeployment()
+	right.Spec.Replicas = 3
+	right.Spec.Labels["team"] = "ops"
+	delete(right.Spec.Labels, "app")
+
+	result := Diff(left, right)
+	assert.Equal(t, "spec.replicas: 2 -> 3\nspec.labels[app]: removed \"web\"\nspec.labels[team]: added \"ops\"", result.String())
+
+	data, err := json.Marshal(result)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[
+		{"path": "spec.replicas", "type": "modified", "left": 2, "right": 3},
+		{"path": "spec.labels[app]", "type": "removed", "left": "web"},
+		{"path": "spec.labels[team]", "type": "added", "right": "ops"}
+	]`, string(data))
+
+	data, err = json.Marshal(DiffResult{})
+	assert.Nil(t, err)
+	assert.Equal(t, "[]", string(data))
+}
+
+func TestDiffBuilder(t *testing.T) {
+	left, right := newDeployment(), newDeployment()
+	right.Name = "api"
+	right.Spec.Containers[1].Image = "nginx:2"
+
+	result := NewDiffBuilder().
+		Append("name", left.Name, right.Name).
+		Append("revision", left.Revision, right.Revision).
+		AppendResult("spec", Diff(left.Spec, right.Spec)).
+		Build()
+
+	assert.Equal(t, []string{"name", "spec.containers[1].image"}, result.Paths())
+	assert.Equal(t, 2, result.Len())
+}
@@ -0,0 +1,369 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jwmajors81/golang-commons-lang/equality"
+)
+
+// Controls how slices are matched up when they are compared
+type SliceAlignment int
+
+const (
+	// Compares elements with the same index
+	AlignByIndex SliceAlignment = iota
+	// Aligns elements using the longest common subsequence so that an
+	// insertion or removal is reported once instead of shifting every
+	// following element
+	AlignByLCS
+)
+
+// Option configures how Diff compares values
+type Option func(*config)
+
+type config struct {
+	ignorePaths    map[string]bool
+	ignoreTags     []string
+	nilEqualsEmpty bool
+	alignment      SliceAlignment
+}
+
+// Ignores the provided paths.  Use `[*]` to match any slice index or map key,
+// such as `spec.containers[*].image`.
+func IgnorePaths(paths ...string) Option {
+	return func(c *config) {
+		for _, path := range paths {
+			c.ignorePaths[path] = true
+		}
+	}
+}
+
+// Ignores struct fields whose tag for the provided key is "-", such as `diff:"-"`
+func IgnoreTag(key string) Option {
+	return func(c *config) {
+		c.ignoreTags = append(c.ignoreTags, key)
+	}
+}
+
+// Treats nil slices and maps as equal to empty, non-nil slices and maps
+func NilEqualsEmpty() Option {
+	return func(c *config) {
+		c.nilEqualsEmpty = true
+	}
+}
+
+// Sets how slice elements are aligned before they are compared
+func WithSliceAlignment(alignment SliceAlignment) Option {
+	return func(c *config) {
+		c.alignment = alignment
+	}
+}
+
+var indexPattern = regexp.MustCompile(`\[[^\]]*\]`)
+
+func (c *config) ignored(path string) bool {
+	if len(c.ignorePaths) == 0 {
+		return false
+	}
+	return c.ignorePaths[path] || c.ignorePaths[indexPattern.ReplaceAllString(path, "[*]")]
+}
+
+func (c *config) equalityOptions() []equality.Option {
+	opts := []equality.Option{}
+	for _, tag := range c.ignoreTags {
+		opts = append(opts, equality.IgnoreTag(tag))
+	}
+	if c.nilEqualsEmpty {
+		opts = append(opts, equality.NilEqualsEmpty())
+	}
+	return opts
+}
+
+// Compares two values reflectively and returns every difference found,
+// similar to Apache Commons ReflectionDiffBuilder.  Struct fields are named
+// using their json tag when present.  Unexported fields are not compared.
+func Diff(left, right any, opts ...Option) DiffResult {
+	c := &config{ignorePaths: map[string]bool{}}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	d := &differ{config: c, eqOpts: c.equalityOptions(), visiting: map[visit]bool{}}
+	d.diff("", reflect.ValueOf(left), reflect.ValueOf(right))
+	return DiffResult{Diffs: d.diffs}
+}
+
+// Identifies a pair of pointers, maps or slices being compared, so that
+// values that refer to themselves are not compared forever
+type visit struct {
+	left, right uintptr
+	typ         reflect.Type
+}
+
+type differ struct {
+	*config
+	eqOpts   []equality.Option
+	visiting map[visit]bool
+	diffs    []Difference
+}
+
+func (d *differ) add(path string, changeType ChangeType, left, right reflect.Value) {
+	diff := Difference{Path: path, Type: changeType}
+	if left.IsValid() {
+		diff.Left = left.Interface()
+	}
+	if right.IsValid() {
+		diff.Right = right.Interface()
+	}
+	d.diffs = append(d.diffs, diff)
+}
+
+func (d *differ) equal(left, right reflect.Value) bool {
+	return equality.DeepEqual(left.Interface(), right.Interface(), d.eqOpts...)
+}
+
+func (d *differ) diff(path string, left, right reflect.Value) {
+	if d.ignored(path) {
+		return
+	}
+	if !left.IsValid() || !right.IsValid() {
+		if left.IsValid() != right.IsValid() {
+			d.add(path, Modified, left, right)
+		}
+		return
+	}
+	if left.Type() != right.Type() || leaf(left.Type()) {
+		if left.Type() != right.Type() || !d.equal(left, right) {
+			d.add(path, Modified, left, right)
+		}
+		return
+	}
+
+	switch left.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if left.IsNil() || right.IsNil() {
+			if left.IsNil() != right.IsNil() && !d.equal(left, right) {
+				d.add(path, Modified, left, right)
+			}
+			return
+		}
+		// only the values being compared further up are skipped, so a value
+		// shared by two paths is compared at both
+		key := visit{left: left.Pointer(), right: right.Pointer(), typ: left.Type()}
+		if d.visiting[key] {
+			return
+		}
+		d.visiting[key] = true
+		defer delete(d.visiting, key)
+	case reflect.Interface:
+		if left.IsNil() || right.IsNil() {
+			if left.IsNil() != right.IsNil() {
+				d.add(path, Modified, left, right)
+			}
+			return
+		}
+	}
+
+	switch left.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		d.diff(path, left.Elem(), right.Elem())
+	case reflect.Struct:
+		d.diffStruct(path, left, right)
+	case reflect.Map:
+		d.diffMap(path, left, right)
+	case reflect.Slice, reflect.Array:
+		if d.alignment == AlignByLCS {
+			d.diffSliceLCS(path, left, right)
+		} else {
+			d.diffSliceByIndex(path, left, right)
+		}
+	default:
+		if !d.equal(left, right) {
+			d.add(path, Modified, left, right)
+		}
+	}
+}
+
+// Returns whether values of the type are compared as a whole rather than field by field
+func leaf(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Array:
+		return false
+	case reflect.Struct:
+		if _, ok := typ.MethodByName("Equal"); ok {
+			return true
+		}
+		for i := 0; i < typ.NumField(); i++ {
+			if typ.Field(i).IsExported() {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
+
+func (d *differ) diffStruct(path string, left, right reflect.Value) {
+	typ := left.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || d.ignoredField(field) {
+			continue
+		}
+		d.diff(joinPath(path, fieldName(field)), left.Field(i), right.Field(i))
+	}
+}
+
+func (d *differ) ignoredField(field reflect.StructField) bool {
+	for _, key := range d.ignoreTags {
+		if field.Tag.Get(key) == "-" {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the json name of the field when it has one, otherwise the Go name
+func fieldName(field reflect.StructField) string {
+	if field.Anonymous && field.Tag.Get("json") == "" {
+		return ""
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+type mapKey struct {
+	name  string
+	value reflect.Value
+}
+
+// Compares the entries of two maps in the order of their keys' text.  Keys
+// are matched by value, so keys that print the same, such as 1 and "1" in a
+// map[any]int, are still compared separately.
+func (d *differ) diffMap(path string, left, right reflect.Value) {
+	var keys []mapKey
+	for _, key := range left.MapKeys() {
+		keys = append(keys, mapKey{name: fmt.Sprint(key.Interface()), value: key})
+	}
+	for _, key := range right.MapKeys() {
+		if !left.MapIndex(key).IsValid() {
+			keys = append(keys, mapKey{name: fmt.Sprint(key.Interface()), value: key})
+		}
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		return keys[i].value.Type().String() < keys[j].value.Type().String()
+	})
+
+	for _, entry := range keys {
+		key := entry.value
+		keyPath := fmt.Sprintf("%s[%s]", path, entry.name)
+		if d.ignored(keyPath) {
+			continue
+		}
+		l, r := left.MapIndex(key), right.MapIndex(key)
+		switch {
+		case !r.IsValid():
+			d.add(keyPath, Removed, l, reflect.Value{})
+		case !l.IsValid():
+			d.add(keyPath, Added, reflect.Value{}, r)
+		default:
+			d.diff(keyPath, l, r)
+		}
+	}
+}
+
+func indexPath(path string, index int) string {
+	return fmt.Sprintf("%s[%d]", path, index)
+}
+
+func (d *differ) diffSliceByIndex(path string, left, right reflect.Value) {
+	for i := 0; i < left.Len() || i < right.Len(); i++ {
+		switch {
+		case i >= right.Len():
+			d.addIfNotIgnored(indexPath(path, i), Removed, left.Index(i), reflect.Value{})
+		case i >= left.Len():
+			d.addIfNotIgnored(indexPath(path, i), Added, reflect.Value{}, right.Index(i))
+		default:
+			d.diff(indexPath(path, i), left.Index(i), right.Index(i))
+		}
+	}
+}
+
+func (d *differ) addIfNotIgnored(path string, changeType ChangeType, left, right reflect.Value) {
+	if !d.ignored(path) {
+		d.add(path, changeType, left, right)
+	}
+}
+
+// Aligns the slices using their longest common subsequence.  Removed elements
+// are reported with their left index and added elements with their right
+// index.  A removal and an addition at the same position are compared with
+// each other and reported using the right index.
+func (d *differ) diffSliceLCS(path string, left, right reflect.Value) {
+	n, m := left.Len(), right.Len()
+	lengths := make([][]int, n+1)
+	for i := range lengths {
+		lengths[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if d.equal(left.Index(i), right.Index(j)) {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	var removed, added []int
+	flush := func() {
+		paired := len(removed)
+		if len(added) < paired {
+			paired = len(added)
+		}
+		for k := 0; k < paired; k++ {
+			d.diff(indexPath(path, added[k]), left.Index(removed[k]), right.Index(added[k]))
+		}
+		for _, i := range removed[paired:] {
+			d.addIfNotIgnored(indexPath(path, i), Removed, left.Index(i), reflect.Value{})
+		}
+		for _, j := range added[paired:] {
+			d.addIfNotIgnored(indexPath(path, j), Added, reflect.Value{}, right.Index(j))
+		}
+		removed, added = nil, nil
+	}
+
+	i, j := 0, 0
+	for i < n && j < m {
+		if d.equal(left.Index(i), right.Index(j)) {
+			flush()
+			i++
+			j++
+		} else if lengths[i+1][j] >= lengths[i][j+1] {
+			removed = append(removed, i)
+			i++
+		} else {
+			added = append(added, j)
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		removed = append(removed, i)
+	}
+	for ; j < m; j++ {
+		added = append(added, j)
+	}
+	flush()
+}
@@ -0,0 +1,56 @@
+package equality
+
+// Builds an equality check one field at a time, similar to Apache Commons
+// EqualsBuilder.  Once a comparison fails all further comparisons are skipped.
+//
+//	equal := NewEqualsBuilder().
+//		Append(a.Name, b.Name).
+//		Append(a.Tags, b.Tags).
+//		IsEquals()
+type EqualsBuilder struct {
+	equal bool
+	opts  []Option
+}
+
+// Creates a builder that compares appended values using DeepEqual with the options provided
+func NewEqualsBuilder(opts ...Option) *EqualsBuilder {
+	return &EqualsBuilder{equal: true, opts: opts}
+}
+
+// Compares the left and right values and records the result
+func (b *EqualsBuilder) Append(left, right any) *EqualsBuilder {
+	if !b.equal {
+		return b
+	}
+	b.equal = DeepEqual(left, right, b.opts...)
+	return b
+}
+
+// Records the result of a comparison that was performed elsewhere, such as
+// the equality check of an embedded type
+func (b *EqualsBuilder) AppendSuper(superEquals bool) *EqualsBuilder {
+	if !b.equal {
+		return b
+	}
+	b.equal = superEquals
+	return b
+}
+
+// Compares the left and right values using a custom function and records the result
+func (b *EqualsBuilder) AppendFunc(equal func() bool) *EqualsBuilder {
+	if !b.equal {
+		return b
+	}
+	b.equal = equal()
+	return b
+}
+
+// Returns whether every comparison appended so far was equal
+func (b *EqualsBuilder) IsEquals() bool {
+	return b.equal
+}
+
+// Resets the builder so it can be reused
+func (b *EqualsBuilder) Reset() {
+	b.equal = true
+}
@@ -0,0 +1,276 @@
+package equality
+
+import (
+	"math"
+	"reflect"
+)
+
+// Option configures how DeepEqual and EqualsBuilder compare values
+type Option func(*config)
+
+type config struct {
+	ignoreFields    map[string]bool
+	ignoreTags      []string
+	nilEqualsEmpty  bool
+	floatEpsilon    float64
+	unorderedSlices bool
+	comparators     map[reflect.Type]func(a, b reflect.Value) bool
+}
+
+// Ignores struct fields with any of the provided names, at any depth
+func IgnoreFields(names ...string) Option {
+	return func(c *config) {
+		for _, name := range names {
+			c.ignoreFields[name] = true
+		}
+	}
+}
+
+// Ignores struct fields whose tag for the provided key is "-", such as `equality:"-"`
+func IgnoreTag(key string) Option {
+	return func(c *config) {
+		c.ignoreTags = append(c.ignoreTags, key)
+	}
+}
+
+// Treats nil slices and maps as equal to empty, non-nil slices and maps
+func NilEqualsEmpty() Option {
+	return func(c *config) {
+		c.nilEqualsEmpty = true
+	}
+}
+
+// Treats floating point values as equal when they differ by no more than epsilon
+func FloatEpsilon(epsilon float64) Option {
+	return func(c *config) {
+		c.floatEpsilon = math.Abs(epsilon)
+	}
+}
+
+// Compares slices and arrays without regard to the order of their elements
+func UnorderedSlices() Option {
+	return func(c *config) {
+		c.unorderedSlices = true
+	}
+}
+
+// Uses the provided function whenever two values of type T are compared.
+// When T is an interface type the function is not called for nil values; two
+// nil values are equal and a nil value never equals a value that is not nil.
+func WithComparator[T any](compare func(a, b T) bool) Option {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	return func(c *config) {
+		c.comparators[typ] = func(a, b reflect.Value) bool {
+			aNil := !a.IsValid() || (a.Kind() == reflect.Interface && a.IsNil())
+			bNil := !b.IsValid() || (b.Kind() == reflect.Interface && b.IsNil())
+			if aNil || bNil {
+				return aNil == bNil
+			}
+			return compare(a.Interface().(T), b.Interface().(T))
+		}
+	}
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		ignoreFields: map[string]bool{},
+		comparators:  map[reflect.Type]func(a, b reflect.Value) bool{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Returns whether the field should be skipped when comparing structs
+func (c *config) ignored(field reflect.StructField) bool {
+	if c.ignoreFields[field.Name] {
+		return true
+	}
+	for _, key := range c.ignoreTags {
+		if field.Tag.Get(key) == "-" {
+			return true
+		}
+	}
+	return false
+}
+
+// Reports whether two values are deeply equal.  With no options the result
+// matches reflect.DeepEqual, except that types with an `Equal(T) bool` method
+// (such as time.Time) are compared using that method.  Cyclic data
+// structures are supported.
+func DeepEqual(a, b any, opts ...Option) bool {
+	c := newConfig(opts)
+	return c.equal(reflect.ValueOf(a), reflect.ValueOf(b), map[visit]bool{})
+}
+
+type visit struct {
+	a, b uintptr
+	typ  reflect.Type
+}
+
+const equalMethodName = "Equal"
+
+func (c *config) equal(a, b reflect.Value, visited map[visit]bool) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	if compare, ok := c.comparators[a.Type()]; ok && a.CanInterface() && b.CanInterface() {
+		return compare(a, b)
+	}
+	if eq, ok := equalMethod(a, b); ok {
+		return eq
+	}
+
+	if hard(a.Kind()) && !a.IsNil() && !b.IsNil() {
+		key := visit{a: a.Pointer(), b: b.Pointer(), typ: a.Type()}
+		if key.a > key.b {
+			key.a, key.b = key.b, key.a
+		}
+		if visited[key] {
+			return true
+		}
+		visited[key] = true
+	}
+
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return c.floatEqual(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		ac, bc := a.Complex(), b.Complex()
+		return c.floatEqual(real(ac), real(bc)) && c.floatEqual(imag(ac), imag(bc))
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	case reflect.Func:
+		return a.IsNil() && b.IsNil()
+	case reflect.Pointer:
+		if a.Pointer() == b.Pointer() {
+			return true
+		}
+		return c.equal(a.Elem(), b.Elem(), visited)
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return c.equal(a.Elem(), b.Elem(), visited)
+	case reflect.Slice:
+		if a.IsNil() != b.IsNil() && !(c.nilEqualsEmpty && a.Len() == 0 && b.Len() == 0) {
+			return false
+		}
+		return c.sequenceEqual(a, b, visited)
+	case reflect.Array:
+		return c.sequenceEqual(a, b, visited)
+	case reflect.Map:
+		if a.IsNil() != b.IsNil() && !(c.nilEqualsEmpty && a.Len() == 0 && b.Len() == 0) {
+			return false
+		}
+		if a.Len() != b.Len() {
+			return false
+		}
+		iter := a.MapRange()
+		for iter.Next() {
+			other := b.MapIndex(iter.Key())
+			if !other.IsValid() || !c.equal(iter.Value(), other, visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if c.ignored(a.Type().Field(i)) {
+				continue
+			}
+			if !c.equal(a.Field(i), b.Field(i), visited) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+func hard(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Map, reflect.Slice, reflect.Pointer:
+		return true
+	}
+	return false
+}
+
+func (c *config) floatEqual(a, b float64) bool {
+	if a == b {
+		return true
+	}
+	return c.floatEpsilon > 0 && math.Abs(a-b) <= c.floatEpsilon
+}
+
+// Compares slices and arrays either positionally or, when configured, as multisets
+func (c *config) sequenceEqual(a, b reflect.Value, visited map[visit]bool) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	if !c.unorderedSlices {
+		for i := 0; i < a.Len(); i++ {
+			if !c.equal(a.Index(i), b.Index(i), visited) {
+				return false
+			}
+		}
+		return true
+	}
+
+	matched := make([]bool, b.Len())
+	for i := 0; i < a.Len(); i++ {
+		found := false
+		for j := 0; j < b.Len(); j++ {
+			if matched[j] {
+				continue
+			}
+			// a failed trial must not leave visit markers behind
+			trial := make(map[visit]bool, len(visited))
+			for k, v := range visited {
+				trial[k] = v
+			}
+			if c.equal(a.Index(i), b.Index(j), trial) {
+				matched[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Uses a method of the form `func (T) Equal(T) bool` when the type provides one
+func equalMethod(a, b reflect.Value) (bool, bool) {
+	if a.Kind() == reflect.Interface || !a.CanInterface() || !b.CanInterface() {
+		return false, false
+	}
+	method, ok := a.Type().MethodByName(equalMethodName)
+	if !ok {
+		return false, false
+	}
+	fnType := method.Type
+	if fnType.NumIn() != 2 || fnType.NumOut() != 1 || fnType.In(1) != a.Type() || fnType.Out(0).Kind() != reflect.Bool {
+		return false, false
+	}
+	if a.Kind() == reflect.Pointer && (a.IsNil() || b.IsNil()) {
+		return a.IsNil() && b.IsNil(), true
+	}
+	return method.Func.Call([]reflect.Value{a, b})[0].Bool(), true
+}
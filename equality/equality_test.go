@@ -0,0 +1,172 @@
+package equality
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Address struct {
+	Street string
+	City   string
+}
+
+type Person struct {
+	Name     string
+	Age      int
+	Updated  time.Time `equality:"-"`
+	Tags     []string
+	Address  *Address
+	Scores   map[string]float64
+	internal int
+}
+
+type Node struct {
+	Value int
+	Next  *Node
+}
+
+func TestDeepEqual(t *testing.T) {
+	var tests = map[string]struct {
+		a, b     any
+		opts     []Option
+		expected bool
+	}{
+		"nil values":             {a: nil, b: nil, expected: true},
+		"nil and value":          {a: nil, b: 1, expected: false},
+		"different types":        {a: int32(1), b: int64(1), expected: false},
+		"equal strings":          {a: "abc", b: "abc", expected: true},
+		"different strings":      {a: "abc", b: "abd", expected: false},
+		"equal slices":           {a: []int{1, 2}, b: []int{1, 2}, expected: true},
+		"different slice length": {a: []int{1, 2}, b: []int{1}, expected: false},
+		"nil and empty slice":    {a: []int(nil), b: []int{}, expected: false},
+		"nil and empty slice with option": {
+			a: []int(nil), b: []int{}, opts: []Option{NilEqualsEmpty()}, expected: true,
+		},
+		"nil and empty map": {a: map[string]int(nil), b: map[string]int{}, expected: false},
+		"nil and empty map with option": {
+			a: map[string]int(nil), b: map[string]int{}, opts: []Option{NilEqualsEmpty()}, expected: true,
+		},
+		"equal maps":     {a: map[string]int{"a": 1}, b: map[string]int{"a": 1}, expected: true},
+		"different maps": {a: map[string]int{"a": 1}, b: map[string]int{"b": 1}, expected: false},
+		"floats within epsilon": {
+			a: 1.0, b: 1.0000001, opts: []Option{FloatEpsilon(1e-6)}, expected: true,
+		},
+		"floats outside epsilon": {
+			a: 1.0, b: 1.001, opts: []Option{FloatEpsilon(1e-6)}, expected: false,
+		},
+		"floats without epsilon": {a: 1.0, b: 1.0000001, expected: false},
+		"unordered slices": {
+			a: []string{"a", "b", "b"}, b: []string{"b", "a", "b"}, opts: []Option{UnorderedSlices()}, expected: true,
+		},
+		"unordered slices with different counts": {
+			a: []string{"a", "a", "b"}, b: []string{"b", "a", "b"}, opts: []Option{UnorderedSlices()}, expected: false,
+		},
+		"ordered slices": {a: []string{"a", "b"}, b: []string{"b", "a"}, expected: false},
+		"arrays":         {a: [2]int{1, 2}, b: [2]int{1, 2}, expected: true},
+		"custom comparator": {
+			a: "ABC", b: "abc", opts: []Option{WithComparator(strings.EqualFold)}, expected: true,
+		},
+		"equal method": {
+			a:        time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC),
+			b:        time.Date(2022, 1, 1, 7, 0, 0, 0, time.FixedZone("EST", -5*60*60)),
+			expected: true,
+		},
+		"pointers to equal values": {a: &Address{City: "x"}, b: &Address{City: "x"}, expected: true},
+		"nil pointer":              {a: &Address{City: "x"}, b: (*Address)(nil), expected: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, DeepEqual(test.a, test.b, test.opts...))
+		})
+	}
+}
+
+func TestComparatorWithNilInterfaces(t *testing.T) {
+	type result struct {
+		Err error
+	}
+	sameMessage := WithComparator(func(a, b error) bool {
+		return a.Error() == b.Error()
+	})
+
+	assert.True(t, DeepEqual(result{}, result{}, sameMessage))
+	assert.False(t, DeepEqual(result{}, result{Err: errors.New("failed")}, sameMessage))
+	assert.False(t, DeepEqual(result{Err: errors.New("failed")}, result{}, sameMessage))
+	assert.True(t, DeepEqual(result{Err: errors.New("failed")}, result{Err: fmt.Errorf("failed")}, sameMessage))
+	assert.False(t, DeepEqual(result{Err: errors.New("failed")}, result{Err: errors.New("other")}, sameMessage))
+}
+
+func TestDeepEqualStructs(t *testing.T) {
+	a := Person{Name: "sam", Age: 3, Updated: time.Now(), Tags: []string{"x"}, Address: &Address{City: "y"}, internal: 1}
+	b := Person{Name: "sam", Age: 3, Updated: time.Now().Add(time.Hour), Tags: []string{"x"}, Address: &Address{City: "y"}, internal: 1}
+
+	assert.False(t, DeepEqual(a, b))
+	assert.True(t, DeepEqual(a, b, IgnoreTag("equality")))
+	assert.True(t, DeepEqual(a, b, IgnoreFields("Updated")))
+
+	b.internal = 2
+	assert.False(t, DeepEqual(a, b, IgnoreTag("equality")))
+	assert.True(t, DeepEqual(a, b, IgnoreFields("Updated", "internal")))
+
+	b.Address.City = "z"
+	assert.False(t, DeepEqual(a, b, IgnoreFields("Updated", "internal")))
+	assert.True(t, DeepEqual(a, b, IgnoreFields("Updated", "internal", "City")))
+}
+
+func TestDeepEqualNaN(t *testing.T) {
+	assert.False(t, DeepEqual(math.NaN(), math.NaN()))
+}
+
+func TestDeepEqualCycles(t *testing.T) {
+	a := &Node{Value: 1}
+	a.Next = &Node{Value: 2, Next: a}
+	b := &Node{Value: 1}
+	b.Next = &Node{Value: 2, Next: b}
+
+	assert.True(t, DeepEqual(a, b))
+
+	b.Next.Value = 3
+	assert.False(t, DeepEqual(a, b))
+}
+
+func TestDeepEqualUnorderedCycles(t *testing.T) {
+	a := &Node{Value: 1}
+	a.Next = a
+	b := &Node{Value: 1}
+	b.Next = b
+	c := &Node{Value: 2}
+	c.Next = c
+
+	assert.True(t, DeepEqual([]*Node{a, c}, []*Node{c, b}, UnorderedSlices()))
+	assert.False(t, DeepEqual([]*Node{a, a}, []*Node{c, b}, UnorderedSlices()))
+}
+
+func TestEqualsBuilder(t *testing.T) {
+	a := Person{Name: "sam", Age: 3, Tags: []string{}}
+	b := Person{Name: "sam", Age: 4}
+
+	assert.True(t, NewEqualsBuilder().IsEquals())
+	assert.True(t, NewEqualsBuilder().Append(a.Name, b.Name).IsEquals())
+	assert.False(t, NewEqualsBuilder().Append(a.Name, b.Name).Append(a.Age, b.Age).IsEquals())
+	assert.False(t, NewEqualsBuilder().Append(a.Tags, b.Tags).IsEquals())
+	assert.True(t, NewEqualsBuilder(NilEqualsEmpty()).Append(a.Tags, b.Tags).IsEquals())
+	assert.False(t, NewEqualsBuilder().AppendSuper(false).Append(a.Name, b.Name).IsEquals())
+
+	called := false
+	builder := NewEqualsBuilder().Append(a.Age, b.Age).AppendFunc(func() bool {
+		called = true
+		return true
+	})
+	assert.False(t, builder.IsEquals())
+	assert.False(t, called)
+
+	builder.Reset()
+	assert.True(t, builder.IsEquals())
+}
@@ -0,0 +1,122 @@
+package errorsutil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Returns the innermost error of the chain, the error that started it all.
+// When an error wraps several errors, as those created by errors.Join or
+// MultiError do, the first of them is followed.  Returns nil for nil.
+func RootCause(err error) error {
+	for {
+		next := unwrapFirst(err)
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+}
+
+// Returns the error and every error it wraps, in the order errors.Is
+// examines them: depth first, with the errors wrapped by a multi-error in
+// the order they were added
+func Chain(err error) []error {
+	var chain []error
+	var walk func(err error)
+	walk = func(err error) {
+		if err == nil {
+			return
+		}
+		chain = append(chain, err)
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap())
+		case interface{ Unwrap() []error }:
+			for _, wrapped := range e.Unwrap() {
+				walk(wrapped)
+			}
+		}
+	}
+	walk(err)
+	return chain
+}
+
+func unwrapFirst(err error) error {
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return e.Unwrap()
+	case interface{ Unwrap() []error }:
+		for _, wrapped := range e.Unwrap() {
+			if wrapped != nil {
+				return wrapped
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the index in the Chain of the first error whose type is T, or -1
+// when there is none.  Unlike errors.As, an error's own As method is not
+// consulted.
+func IndexOfType[T error](err error) int {
+	for i, e := range Chain(err) {
+		if _, ok := e.(T); ok {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns the first error in the chain that errors.As can assign to a T
+func FindAs[T any](err error) (T, bool) {
+	var target T
+	if err == nil {
+		return target, false
+	}
+	ok := errors.As(err, &target)
+	return target, ok
+}
+
+// Returns a description of the error and every error it wraps, similar to
+// Apache Commons ExceptionUtils.getStackTrace.  Each error is written with
+// its type on a line of its own, followed by its stack when it has one.  The
+// message of the error it wraps is trimmed from the end of each message so
+// that it is not repeated.
+//
+//	save failed (*errorsutil.withStack)
+//		at main.save (/src/main.go:12)
+//	Caused by: disk full (*errors.errorString)
+func FormatChain(err error, filters ...FrameFilter) string {
+	var sb strings.Builder
+	for i, e := range Chain(err) {
+		if i > 0 {
+			sb.WriteString("\nCaused by: ")
+		}
+		fmt.Fprintf(&sb, "%s (%T)", ownMessage(e), e)
+		if tracer, ok := e.(StackTracer); ok {
+			for _, frame := range tracer.Stack().Frames(filters...) {
+				fmt.Fprintf(&sb, "\n\tat %s (%s:%d)", frame.Function, frame.File, frame.Line)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// Returns the message of the error without the message of the error it wraps
+func ownMessage(err error) string {
+	message := err.Error()
+	wrapped, ok := err.(interface{ Unwrap() error })
+	if !ok || wrapped.Unwrap() == nil {
+		return message
+	}
+	cause := wrapped.Unwrap().Error()
+	if trimmed := strings.TrimSuffix(message, cause); trimmed != message {
+		trimmed = strings.TrimSuffix(strings.TrimRight(trimmed, " "), ":")
+		if trimmed != "" {
+			return trimmed
+		}
+	}
+	return message
+}
@@ -0,0 +1,115 @@
+package errorsutil
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type codeError struct {
+	code int
+}
+
+func (e *codeError) Error() string {
+	return fmt.Sprintf("code %d", e.code)
+}
+
+var errRoot = errors.New("root")
+
+func TestRootCause(t *testing.T) {
+	var tests = map[string]struct {
+		err      error
+		expected error
+	}{
+		"nil":          {err: nil, expected: nil},
+		"unwrapped":    {err: errRoot, expected: errRoot},
+		"wrapped":      {err: fmt.Errorf("b: %w", fmt.Errorf("a: %w", errRoot)), expected: errRoot},
+		"with stack":   {err: Wrap(errRoot, "context"), expected: errRoot},
+		"multi error":  {err: fmt.Errorf("outer: %w", Join(errRoot, fs.ErrNotExist)), expected: errRoot},
+		"not wrapping": {err: fmt.Errorf("b: %v", errRoot), expected: nil},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := RootCause(test.err)
+			if test.expected == nil && test.err != nil {
+				assert.Same(t, test.err, actual)
+			} else {
+				assert.Equal(t, test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	code := &codeError{code: 404}
+	middle := fmt.Errorf("middle: %w", code)
+	multi := NewMultiError().Append(middle, errRoot)
+	top := fmt.Errorf("top: %w", multi)
+
+	assert.Equal(t, []error{top, multi, middle, code, errRoot}, Chain(top))
+	assert.Nil(t, Chain(nil))
+}
+
+func TestIndexOfType(t *testing.T) {
+	code := &codeError{code: 500}
+	err := fmt.Errorf("handler: %w", fmt.Errorf("service: %w", code))
+
+	assert.Equal(t, 2, IndexOfType[*codeError](err))
+	assert.Equal(t, -1, IndexOfType[*fs.PathError](err))
+	assert.Equal(t, -1, IndexOfType[*codeError](nil))
+}
+
+func TestFindAs(t *testing.T) {
+	err := fmt.Errorf("loading: %w", &fs.PathError{Op: "open", Path: "/etc/app.conf", Err: fs.ErrNotExist})
+
+	pathErr, ok := FindAs[*fs.PathError](err)
+	assert.True(t, ok)
+	assert.Equal(t, "/etc/app.conf", pathErr.Path)
+
+	_, ok = FindAs[*codeError](err)
+	assert.False(t, ok)
+
+	_, ok = FindAs[*codeError](nil)
+	assert.False(t, ok)
+
+	// interfaces work as well as concrete types
+	timeout, ok := FindAs[interface{ Timeout() bool }](err)
+	assert.True(t, ok)
+	assert.False(t, timeout.Timeout())
+}
+
+func TestFormatChain(t *testing.T) {
+	err := fmt.Errorf("handling request: %w", Wrap(&codeError{code: 503}, "calling backend"))
+
+	output := FormatChain(err, SkipRuntime())
+	lines := strings.Split(output, "\n")
+
+	assert.Equal(t, "handling request (*fmt.wrapError)", lines[0])
+	assert.Equal(t, "Caused by: calling backend (*errorsutil.withStack)", lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], "\tat github.com/jwmajors81/golang-commons-lang/errorsutil.TestFormatChain ("), lines[2])
+	assert.Equal(t, "Caused by: code 503 (*errorsutil.codeError)", lines[len(lines)-1])
+	assert.NotContains(t, output, "testing.tRunner")
+}
+
+func TestFormatChainKeepsMessagesThatDoNotRepeatTheCause(t *testing.T) {
+	err := &customWrapper{err: errRoot}
+	assert.Equal(t, "custom (*errorsutil.customWrapper)\nCaused by: root (*errors.errorString)", FormatChain(err))
+	assert.Equal(t, "", FormatChain(nil))
+}
+
+type customWrapper struct {
+	err error
+}
+
+func (w *customWrapper) Error() string {
+	return "custom"
+}
+
+func (w *customWrapper) Unwrap() error {
+	return w.err
+}
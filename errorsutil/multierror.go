@@ -0,0 +1,156 @@
+package errorsutil
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Configures a MultiError
+type MultiOption func(*MultiError)
+
+// Ignores errors with the same type and message as an error already added
+func Deduplicate() MultiOption {
+	return func(m *MultiError) {
+		m.seen = map[string]bool{}
+	}
+}
+
+// Sorts the errors by message, so that errors added by several goroutines
+// are reported in the same order every time
+func SortByMessage() MultiOption {
+	return func(m *MultiError) {
+		m.sorted = true
+	}
+}
+
+// Collects several errors into one.  Its Error method joins the messages with
+// newlines like errors.Join, and it unwraps to the errors collected so that
+// errors.Is and errors.As examine each of them.  A MultiError is safe for use
+// by multiple goroutines.
+//
+//	var errs errorsutil.MultiError
+//	for _, item := range items {
+//		errs.Append(process(item))
+//	}
+//	return errs.ErrorOrNil()
+type MultiError struct {
+	mu     sync.Mutex
+	errs   []error
+	seen   map[string]bool
+	sorted bool
+}
+
+// Creates an empty MultiError
+func NewMultiError(opts ...MultiOption) *MultiError {
+	m := &MultiError{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Combines the errors that are not nil like errors.Join, returning nil when
+// they are all nil
+func Join(errs ...error) error {
+	m := NewMultiError()
+	m.Append(errs...)
+	return m.ErrorOrNil()
+}
+
+// Adds the errors that are not nil.  The errors of another MultiError, or
+// of any error with an Unwrap() []error method such as those returned by
+// errors.Join, are added individually.
+func (m *MultiError) Append(errs ...error) *MultiError {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, err := range errs {
+		m.append(err)
+	}
+	return m
+}
+
+func (m *MultiError) append(err error) {
+	if err == nil {
+		return
+	}
+	if multi, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, wrapped := range multi.Unwrap() {
+			m.append(wrapped)
+		}
+		return
+	}
+	if m.seen != nil {
+		key := fmt.Sprintf("%T:%s", err, err.Error())
+		if m.seen[key] {
+			return
+		}
+		m.seen[key] = true
+	}
+	m.errs = append(m.errs, err)
+}
+
+// Returns a copy of the errors collected
+func (m *MultiError) Errors() []error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	errs := append([]error(nil), m.errs...)
+	if m.sorted {
+		sort.SliceStable(errs, func(i, j int) bool {
+			return errs[i].Error() < errs[j].Error()
+		})
+	}
+	return errs
+}
+
+// Returns the number of errors collected
+func (m *MultiError) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.errs)
+}
+
+// Returns the MultiError when it holds any errors, or nil
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || m.Len() == 0 {
+		return nil
+	}
+	return m
+}
+
+// Returns the messages of the errors separated by newlines
+func (m *MultiError) Error() string {
+	errs := m.Errors()
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Returns the errors collected
+func (m *MultiError) Unwrap() []error {
+	return m.Errors()
+}
+
+// Reports whether any of the errors matches the target
+func (m *MultiError) Is(target error) bool {
+	for _, err := range m.Errors() {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Finds the first of the errors that matches the target
+func (m *MultiError) As(target any) bool {
+	for _, err := range m.Errors() {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package errorsutil
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiError(t *testing.T) {
+	var errs MultiError
+	assert.Nil(t, errs.ErrorOrNil())
+
+	errs.Append(nil, errRoot, nil)
+	errs.Append(&codeError{code: 1})
+
+	err := errs.ErrorOrNil()
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, errs.Len())
+	assert.EqualError(t, err, "root\ncode 1")
+	assert.ErrorIs(t, err, errRoot)
+	assert.False(t, errors.Is(err, fs.ErrNotExist))
+
+	code, ok := FindAs[*codeError](err)
+	assert.True(t, ok)
+	assert.Equal(t, 1, code.code)
+
+	var nilMulti *MultiError
+	assert.Nil(t, nilMulti.ErrorOrNil())
+}
+
+// Wraps several errors the way errors.Join does
+type joined []error
+
+func (j joined) Error() string {
+	return "joined"
+}
+
+func (j joined) Unwrap() []error {
+	return j
+}
+
+func TestJoin(t *testing.T) {
+	first := errors.New("first")
+	second := errors.New("second")
+
+	err := Join(first, nil, second)
+	assert.EqualError(t, err, "first\nsecond")
+	assert.Equal(t, []error{first, second}, err.(interface{ Unwrap() []error }).Unwrap())
+	assert.Nil(t, Join(nil, nil))
+}
+
+func TestMultiErrorFlattens(t *testing.T) {
+	a, b, c := errors.New("a"), errors.New("b"), errors.New("c")
+	multi := NewMultiError().Append(joined{a, b}, NewMultiError().Append(c))
+	assert.Equal(t, []error{a, b, c}, multi.Errors())
+}
+
+func TestMultiErrorOptions(t *testing.T) {
+	var tests = map[string]struct {
+		opts     []MultiOption
+		expected string
+	}{
+		"as added":    {expected: "timeout\nrefused\ntimeout"},
+		"deduplicate": {opts: []MultiOption{Deduplicate()}, expected: "timeout\nrefused"},
+		"sorted":      {opts: []MultiOption{SortByMessage()}, expected: "refused\ntimeout\ntimeout"},
+		"both":        {opts: []MultiOption{Deduplicate(), SortByMessage()}, expected: "refused\ntimeout"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			multi := NewMultiError(test.opts...)
+			multi.Append(errors.New("timeout"), errors.New("refused"), errors.New("timeout"))
+			assert.EqualError(t, multi, test.expected)
+		})
+	}
+}
+
+func TestMultiErrorDeduplicateConsidersType(t *testing.T) {
+	multi := NewMultiError(Deduplicate())
+	multi.Append(errors.New("code 1"), &codeError{code: 1}, fmt.Errorf("code %d", 1))
+
+	// fmt.Errorf without %w creates the same type as errors.New
+	assert.Equal(t, 2, multi.Len())
+}
+
+func TestMultiErrorConcurrentAppend(t *testing.T) {
+	multi := NewMultiError(SortByMessage())
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			multi.Append(fmt.Errorf("worker %d failed", i))
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, multi.Len())
+	assert.Equal(t, "worker 0 failed", multi.Errors()[0].Error())
+	assert.Equal(t, "worker 9 failed", multi.Errors()[9].Error())
+}
@@ -0,0 +1,140 @@
+package errorsutil
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+)
+
+const maxStackDepth = 32
+
+// The program counters of a call stack, captured by Callers
+type Stack []uintptr
+
+// Implemented by errors that carry the stack where they were created
+type StackTracer interface {
+	Stack() Stack
+}
+
+// Decides whether a frame is included by Stack.Frames
+type FrameFilter func(frame runtime.Frame) bool
+
+// Captures the stack of the caller.  The skip is the number of additional
+// callers to leave out, so a helper that captures the stack of its own
+// caller passes 1.
+func Callers(skip int) Stack {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip+2, pcs)
+	return pcs[:n]
+}
+
+// Returns the frames of the stack, innermost first, that every filter includes
+func (s Stack) Frames(filters ...FrameFilter) []runtime.Frame {
+	var frames []runtime.Frame
+	if len(s) == 0 {
+		return frames
+	}
+	iterator := runtime.CallersFrames(s)
+	for {
+		frame, more := iterator.Next()
+		if include(frame, filters) {
+			frames = append(frames, frame)
+		}
+		if !more {
+			return frames
+		}
+	}
+}
+
+func include(frame runtime.Frame, filters []FrameFilter) bool {
+	for _, filter := range filters {
+		if !filter(frame) {
+			return false
+		}
+	}
+	return true
+}
+
+// Leaves out the frames of functions in packages whose import path starts
+// with one of the prefixes
+func SkipPackages(prefixes ...string) FrameFilter {
+	return func(frame runtime.Frame) bool {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(frame.Function, prefix+".") || strings.HasPrefix(frame.Function, prefix+"/") {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Leaves out the frames of the runtime and testing packages
+func SkipRuntime() FrameFilter {
+	return SkipPackages("runtime", "testing")
+}
+
+type withStack struct {
+	message string
+	err     error
+	stack   Stack
+}
+
+// Records the stack of the caller with the error.  Returns nil for nil.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &withStack{err: err, stack: Callers(1)}
+}
+
+// Wraps the error with a message and the stack of the caller, the message
+// of the result is "message: cause".  Returns nil for nil.
+func Wrap(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	return &withStack{message: message, err: err, stack: Callers(1)}
+}
+
+func (w *withStack) Error() string {
+	if w.message == "" {
+		return w.err.Error()
+	}
+	return w.message + ": " + w.err.Error()
+}
+
+func (w *withStack) Unwrap() error {
+	return w.err
+}
+
+func (w *withStack) Stack() Stack {
+	return w.stack
+}
+
+// Supports %+v to write the message followed by the stack
+func (w *withStack) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		io.WriteString(s, w.Error())
+		for _, frame := range w.stack.Frames() {
+			fmt.Fprintf(s, "\n\t%s\n\t\t%s:%d", frame.Function, frame.File, frame.Line)
+		}
+	case verb == 'q':
+		fmt.Fprintf(s, "%q", w.Error())
+	default:
+		io.WriteString(s, w.Error())
+	}
+}
+
+// Returns the stack of the innermost error in the chain that has one
+func StackOf(err error) (Stack, bool) {
+	var stack Stack
+	found := false
+	for _, e := range Chain(err) {
+		if tracer, ok := e.(StackTracer); ok {
+			stack, found = tracer.Stack(), true
+		}
+	}
+	return stack, found
+}
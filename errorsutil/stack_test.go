@@ -0,0 +1,77 @@
+package errorsutil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func captureFromHelper() Stack {
+	return Callers(1)
+}
+
+func TestCallers(t *testing.T) {
+	frames := Callers(0).Frames()
+	assert.True(t, strings.HasSuffix(frames[0].Function, ".TestCallers"), frames[0].Function)
+
+	frames = captureFromHelper().Frames()
+	assert.True(t, strings.HasSuffix(frames[0].Function, ".TestCallers"), frames[0].Function)
+
+	assert.Empty(t, Stack(nil).Frames())
+}
+
+func TestFrameFilters(t *testing.T) {
+	stack := Callers(0)
+
+	all := stack.Frames()
+	filtered := stack.Frames(SkipRuntime())
+	assert.Less(t, len(filtered), len(all))
+	for _, frame := range filtered {
+		assert.False(t, strings.HasPrefix(frame.Function, "runtime."), frame.Function)
+		assert.False(t, strings.HasPrefix(frame.Function, "testing."), frame.Function)
+	}
+
+	assert.Empty(t, stack.Frames(SkipPackages("github.com/jwmajors81/golang-commons-lang/errorsutil"), SkipRuntime()))
+
+	// a prefix only matches whole path elements
+	assert.Len(t, stack.Frames(SkipPackages("run")), len(all))
+}
+
+func TestWrap(t *testing.T) {
+	err := Wrap(errRoot, "loading")
+	assert.EqualError(t, err, "loading: root")
+	assert.ErrorIs(t, err, errRoot)
+
+	stack, ok := StackOf(err)
+	assert.True(t, ok)
+	assert.True(t, strings.HasSuffix(stack.Frames()[0].Function, ".TestWrap"))
+
+	assert.Nil(t, Wrap(nil, "loading"))
+}
+
+func TestWithStack(t *testing.T) {
+	err := WithStack(errRoot)
+	assert.EqualError(t, err, "root")
+	assert.Equal(t, `"root"`, fmt.Sprintf("%q", err))
+	assert.ErrorIs(t, err, errRoot)
+	assert.Nil(t, WithStack(nil))
+
+	detailed := fmt.Sprintf("%+v", err)
+	assert.True(t, strings.HasPrefix(detailed, "root\n\tgithub.com/jwmajors81/golang-commons-lang/errorsutil.TestWithStack\n\t\t"), detailed)
+	assert.Contains(t, detailed, "stack_test.go:")
+}
+
+func TestStackOf(t *testing.T) {
+	inner := WithStack(errRoot)
+	outer := Wrap(fmt.Errorf("middle: %w", inner), "outer")
+
+	stack, ok := StackOf(outer)
+	assert.True(t, ok)
+	assert.Equal(t, inner.(StackTracer).Stack(), stack)
+
+	_, ok = StackOf(errors.New("plain"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,235 @@
+package exception
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+
+	"github.com/jwmajors81/golang-commons-lang/errorsutil"
+)
+
+// A label and value added to a ContextedError
+type ContextEntry struct {
+	Label string
+	Value any
+}
+
+// An error that carries ordered label and value pairs describing the
+// circumstances of the failure, similar to Apache Commons ContextedException.
+// The stack is captured when the error is created.  Labels may be repeated,
+// AddContext keeps every value while SetContext replaces them.
+//
+// Formatting with %v or %s gives the message followed by the context and the
+// cause on one line, %+v gives the message, context, stack and cause over
+// several lines.
+//
+// A ContextedError is not safe for concurrent modification.
+type ContextedError struct {
+	message string
+	cause   error
+	context []ContextEntry
+	stack   errorsutil.Stack
+}
+
+// Creates an error with the message provided
+func NewContextedError(message string) *ContextedError {
+	return &ContextedError{message: message, stack: errorsutil.Callers(1)}
+}
+
+// Creates an error that wraps the cause, the message may be empty
+func WrapContexted(cause error, message string) *ContextedError {
+	return &ContextedError{message: message, cause: cause, stack: errorsutil.Callers(1)}
+}
+
+// Adds a value for the label, keeping any values already added for it
+func (e *ContextedError) AddContext(label string, value any) *ContextedError {
+	e.context = append(e.context, ContextEntry{Label: label, Value: value})
+	return e
+}
+
+// Replaces the values of the label with the value provided.  The entry keeps
+// the position of the first existing value, or is added at the end.
+func (e *ContextedError) SetContext(label string, value any) *ContextedError {
+	index := -1
+	entries := e.context[:0]
+	for _, entry := range e.context {
+		if entry.Label != label {
+			entries = append(entries, entry)
+		} else if index < 0 {
+			index = len(entries)
+			entries = append(entries, ContextEntry{Label: label, Value: value})
+		}
+	}
+	e.context = entries
+	if index < 0 {
+		e.context = append(e.context, ContextEntry{Label: label, Value: value})
+	}
+	return e
+}
+
+// Returns the values added for the label in the order they were added
+func (e *ContextedError) GetContextValues(label string) []any {
+	var values []any
+	for _, entry := range e.context {
+		if entry.Label == label {
+			values = append(values, entry.Value)
+		}
+	}
+	return values
+}
+
+// Returns the first value added for the label, or nil when there is none
+func (e *ContextedError) GetFirstContextValue(label string) any {
+	for _, entry := range e.context {
+		if entry.Label == label {
+			return entry.Value
+		}
+	}
+	return nil
+}
+
+// Returns the distinct labels in the order they were first added
+func (e *ContextedError) GetContextLabels() []string {
+	var labels []string
+	seen := map[string]bool{}
+	for _, entry := range e.context {
+		if !seen[entry.Label] {
+			seen[entry.Label] = true
+			labels = append(labels, entry.Label)
+		}
+	}
+	return labels
+}
+
+// Returns a copy of all the context entries in the order they were added
+func (e *ContextedError) GetContextEntries() []ContextEntry {
+	return append([]ContextEntry(nil), e.context...)
+}
+
+// Returns the message without the context or cause
+func (e *ContextedError) Message() string {
+	return e.message
+}
+
+// Returns the stack captured when the error was created
+func (e *ContextedError) Stack() errorsutil.Stack {
+	return e.stack
+}
+
+// Returns the frames of the stack captured when the error was created
+func (e *ContextedError) StackTrace() []runtime.Frame {
+	return e.stack.Frames()
+}
+
+// Returns the message followed by the context and the cause
+func (e *ContextedError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.message)
+	if len(e.context) > 0 {
+		if sb.Len() > 0 {
+			sb.WriteString(" ")
+		}
+		sb.WriteString("[")
+		for i, entry := range e.context {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			fmt.Fprintf(&sb, "%s=%v", entry.Label, entry.Value)
+		}
+		sb.WriteString("]")
+	}
+	if e.cause != nil {
+		if sb.Len() > 0 {
+			sb.WriteString(": ")
+		}
+		sb.WriteString(e.cause.Error())
+	}
+	return sb.String()
+}
+
+// Returns the wrapped error
+func (e *ContextedError) Unwrap() error {
+	return e.cause
+}
+
+// Supports %v and %s for the message, %+v for the message, context, stack
+// and cause, and %q for the quoted message
+func (e *ContextedError) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		e.writeDetails(s)
+	case verb == 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	default:
+		io.WriteString(s, e.Error())
+	}
+}
+
+func (e *ContextedError) writeDetails(w io.Writer) {
+	io.WriteString(w, e.message)
+	if len(e.context) > 0 {
+		io.WriteString(w, "\nContext:")
+		for i, entry := range e.context {
+			fmt.Fprintf(w, "\n\t[%d:%s=%v]", i+1, entry.Label, entry.Value)
+		}
+	}
+	if len(e.stack) > 0 {
+		io.WriteString(w, "\nStack:")
+		for _, frame := range e.StackTrace() {
+			fmt.Fprintf(w, "\n\t%s\n\t\t%s:%d", frame.Function, frame.File, frame.Line)
+		}
+	}
+	if e.cause != nil {
+		fmt.Fprintf(w, "\nCaused by: %+v", e.cause)
+	}
+}
+
+type contextedJSON struct {
+	Message string             `json:"message"`
+	Context []contextEntryJSON `json:"context,omitempty"`
+	Stack   []string           `json:"stack,omitempty"`
+	Cause   json.RawMessage    `json:"cause,omitempty"`
+}
+
+type contextEntryJSON struct {
+	Label string          `json:"label"`
+	Value json.RawMessage `json:"value"`
+}
+
+type causeJSON struct {
+	Message string `json:"message"`
+}
+
+// Marshals the message, context, stack and cause.  Context values that
+// cannot be marshaled are written as strings using fmt.  A cause that is a
+// json.Marshaler is marshaled as is, any other cause as an object holding
+// its message.
+func (e *ContextedError) MarshalJSON() ([]byte, error) {
+	out := contextedJSON{Message: e.message}
+	for _, entry := range e.context {
+		value, err := json.Marshal(entry.Value)
+		if err != nil {
+			value, _ = json.Marshal(fmt.Sprint(entry.Value))
+		}
+		out.Context = append(out.Context, contextEntryJSON{Label: entry.Label, Value: value})
+	}
+	for _, frame := range e.StackTrace() {
+		out.Stack = append(out.Stack, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+	}
+	if e.cause != nil {
+		var cause []byte
+		var err error
+		if marshaler, ok := e.cause.(json.Marshaler); ok {
+			cause, err = marshaler.MarshalJSON()
+		} else {
+			cause, err = json.Marshal(causeJSON{Message: e.cause.Error()})
+		}
+		if err != nil {
+			return nil, err
+		}
+		out.Cause = cause
+	}
+	return json.Marshal(out)
+}
@@ -0,0 +1,148 @@
+package exception
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/jwmajors81/golang-commons-lang/errorsutil"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ errorsutil.StackTracer = (*ContextedError)(nil)
+
+func TestContextedErrorContext(t *testing.T) {
+	err := NewContextedError("could not load order").
+		AddContext("orderId", 42).
+		AddContext("attempt", 1).
+		AddContext("attempt", 2).
+		AddContext("region", "eu")
+
+	assert.Equal(t, []any{1, 2}, err.GetContextValues("attempt"))
+	assert.Equal(t, 1, err.GetFirstContextValue("attempt"))
+	assert.Nil(t, err.GetFirstContextValue("missing"))
+	assert.Nil(t, err.GetContextValues("missing"))
+	assert.Equal(t, []string{"orderId", "attempt", "region"}, err.GetContextLabels())
+
+	err.SetContext("attempt", 3).SetContext("user", "bob")
+	assert.Equal(t, []ContextEntry{
+		{Label: "orderId", Value: 42},
+		{Label: "attempt", Value: 3},
+		{Label: "region", Value: "eu"},
+		{Label: "user", Value: "bob"},
+	}, err.GetContextEntries())
+
+	entries := err.GetContextEntries()
+	entries[0].Value = 0
+	assert.Equal(t, 42, err.GetFirstContextValue("orderId"))
+}
+
+func TestContextedErrorMessage(t *testing.T) {
+	cause := errors.New("connection refused")
+
+	var tests = map[string]struct {
+		err      *ContextedError
+		expected string
+	}{
+		"message only":    {err: NewContextedError("failed"), expected: "failed"},
+		"with context":    {err: NewContextedError("failed").AddContext("id", 7).AddContext("name", "x"), expected: "failed [id=7, name=x]"},
+		"with cause":      {err: WrapContexted(cause, "failed"), expected: "failed: connection refused"},
+		"everything":      {err: WrapContexted(cause, "failed").AddContext("host", "db"), expected: "failed [host=db]: connection refused"},
+		"empty message":   {err: WrapContexted(cause, ""), expected: "connection refused"},
+		"context only":    {err: WrapContexted(nil, "").AddContext("a", 1), expected: "[a=1]"},
+		"nested contexts": {err: WrapContexted(NewContextedError("inner").AddContext("a", 1), "outer").AddContext("b", 2), expected: "outer [b=2]: inner [a=1]"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.err.Error())
+			assert.Equal(t, test.expected, fmt.Sprintf("%v", test.err))
+			assert.Equal(t, test.expected, fmt.Sprintf("%s", test.err))
+			assert.Equal(t, fmt.Sprintf("%q", test.expected), fmt.Sprintf("%q", test.err))
+		})
+	}
+}
+
+func TestContextedErrorUnwrap(t *testing.T) {
+	err := WrapContexted(fmt.Errorf("reading config: %w", fs.ErrNotExist), "startup failed")
+	wrapped := fmt.Errorf("main: %w", err)
+
+	assert.ErrorIs(t, wrapped, fs.ErrNotExist)
+
+	var contexted *ContextedError
+	assert.True(t, errors.As(wrapped, &contexted))
+	assert.Same(t, err, contexted)
+	assert.Equal(t, "startup failed", contexted.Message())
+
+	assert.Nil(t, NewContextedError("no cause").Unwrap())
+}
+
+func TestContextedErrorDetailedFormat(t *testing.T) {
+	err := WrapContexted(NewContextedError("disk full").AddContext("path", "/tmp"), "save failed").
+		AddContext("file", "a.txt").
+		AddContext("size", 12)
+
+	output := fmt.Sprintf("%+v", err)
+	lines := strings.Split(output, "\n")
+
+	assert.Equal(t, []string{"save failed", "Context:", "\t[1:file=a.txt]", "\t[2:size=12]", "Stack:"}, lines[:5])
+	assert.Contains(t, lines[5], "exception.TestContextedErrorDetailedFormat")
+	assert.Contains(t, lines[6], "contexted_test.go:")
+	assert.Contains(t, output, "\nCaused by: disk full\nContext:\n\t[1:path=/tmp]\nStack:\n")
+}
+
+func TestContextedErrorStackTrace(t *testing.T) {
+	frames := NewContextedError("failed").StackTrace()
+	assert.NotEmpty(t, frames)
+	assert.True(t, strings.HasSuffix(frames[0].Function, "exception.TestContextedErrorStackTrace"), frames[0].Function)
+
+	assert.Empty(t, (&ContextedError{message: "no stack"}).StackTrace())
+}
+
+func TestContextedErrorMarshalJSON(t *testing.T) {
+	inner := NewContextedError("disk full").AddContext("free", 0)
+	err := WrapContexted(inner, "save failed").
+		AddContext("file", "a.txt").
+		AddContext("tags", []string{"x", "y"}).
+		AddContext("callback", func() {})
+
+	data, marshalErr := json.Marshal(err)
+	assert.Nil(t, marshalErr)
+
+	var decoded struct {
+		Message string `json:"message"`
+		Context []struct {
+			Label string `json:"label"`
+			Value any    `json:"value"`
+		} `json:"context"`
+		Stack []string `json:"stack"`
+		Cause struct {
+			Message string `json:"message"`
+			Context []struct {
+				Label string `json:"label"`
+				Value any    `json:"value"`
+			} `json:"context"`
+		} `json:"cause"`
+	}
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+
+	assert.Equal(t, "save failed", decoded.Message)
+	assert.Len(t, decoded.Context, 3)
+	assert.Equal(t, "a.txt", decoded.Context[0].Value)
+	assert.Equal(t, []any{"x", "y"}, decoded.Context[1].Value)
+	assert.IsType(t, "", decoded.Context[2].Value)
+	assert.NotEmpty(t, decoded.Stack)
+	assert.Contains(t, decoded.Stack[0], "exception.TestContextedErrorMarshalJSON")
+	assert.Equal(t, "disk full", decoded.Cause.Message)
+	assert.Equal(t, "free", decoded.Cause.Context[0].Label)
+}
+
+func TestContextedErrorMarshalJSONPlainCause(t *testing.T) {
+	err := &ContextedError{message: "failed", cause: errors.New("timeout")}
+	data, marshalErr := json.Marshal(err)
+	assert.Nil(t, marshalErr)
+	assert.JSONEq(t, `{"message":"failed","cause":{"message":"timeout"}}`, string(data))
+}
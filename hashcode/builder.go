@@ -0,0 +1,37 @@
+package hashcode
+
+import "reflect"
+
+// Builds a hash code one field at a time, similar to Apache Commons
+// HashCodeBuilder.  Fields should be appended in the same order as they are
+// compared in the matching equality check.
+//
+//	hash := NewHashCodeBuilder().
+//		Append(p.Name).
+//		Append(p.Tags).
+//		HashCode()
+type HashCodeBuilder struct {
+	encoder *encoder
+}
+
+// Creates a builder that hashes appended values using the options provided
+func NewHashCodeBuilder(opts ...Option) *HashCodeBuilder {
+	return &HashCodeBuilder{encoder: &encoder{config: newConfig(opts), visiting: map[visit]bool{}}}
+}
+
+// Adds the value to the hash
+func (b *HashCodeBuilder) Append(value any) *HashCodeBuilder {
+	b.encoder.encode(reflect.ValueOf(value))
+	return b
+}
+
+// Adds a hash code that was calculated elsewhere, such as the hash of an embedded type
+func (b *HashCodeBuilder) AppendSuper(superHashCode uint64) *HashCodeBuilder {
+	b.encoder.writeUint(superHashCode)
+	return b
+}
+
+// Returns the hash of all values appended so far
+func (b *HashCodeBuilder) HashCode() uint64 {
+	return b.encoder.sum(b.encoder.buf.Bytes())
+}
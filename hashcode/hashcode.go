@@ -0,0 +1,328 @@
+package hashcode
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// The hash function used to turn the encoded value into a hash code
+type Algorithm int
+
+const (
+	FNV1a Algorithm = iota
+	XXHash
+	SHA256
+)
+
+// Types implementing Hasher provide their own hash code.  Types that define a
+// custom `Equal` method should implement Hasher so that values considered
+// equal by equality.DeepEqual also hash identically.
+type Hasher interface {
+	HashCode() uint64
+}
+
+// Option configures how values are hashed.  The options mirror those of the
+// equality package: values that are equal under equality.DeepEqual with the
+// matching options produce the same hash.
+type Option func(*config)
+
+type config struct {
+	algorithm       Algorithm
+	seed            uint64
+	ignoreFields    map[string]bool
+	ignoreTags      []string
+	nilEqualsEmpty  bool
+	unorderedSlices bool
+}
+
+// Selects the hash function, FNV1a is used by default
+func WithAlgorithm(algorithm Algorithm) Option {
+	return func(c *config) {
+		c.algorithm = algorithm
+	}
+}
+
+// Seeds the hash so that independent uses produce unrelated hash codes
+func WithSeed(seed uint64) Option {
+	return func(c *config) {
+		c.seed = seed
+	}
+}
+
+// Excludes struct fields with any of the provided names, at any depth
+func IgnoreFields(names ...string) Option {
+	return func(c *config) {
+		for _, name := range names {
+			c.ignoreFields[name] = true
+		}
+	}
+}
+
+// Excludes struct fields whose tag for the provided key is "-", such as `hash:"-"`
+func IgnoreTag(key string) Option {
+	return func(c *config) {
+		c.ignoreTags = append(c.ignoreTags, key)
+	}
+}
+
+// Hashes nil slices and maps the same as empty slices and maps
+func NilEqualsEmpty() Option {
+	return func(c *config) {
+		c.nilEqualsEmpty = true
+	}
+}
+
+// Hashes slices and arrays without regard to the order of their elements
+func UnorderedSlices() Option {
+	return func(c *config) {
+		c.unorderedSlices = true
+	}
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{ignoreFields: map[string]bool{}}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *config) ignored(field reflect.StructField) bool {
+	if c.ignoreFields[field.Name] {
+		return true
+	}
+	for _, key := range c.ignoreTags {
+		if field.Tag.Get(key) == "-" {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the hash of the encoded bytes using the configured algorithm and seed
+func (c *config) sum(data []byte) uint64 {
+	var seed [8]byte
+	binary.LittleEndian.PutUint64(seed[:], c.seed)
+
+	switch c.algorithm {
+	case XXHash:
+		return xxhash64(data, c.seed)
+	case SHA256:
+		h := sha256.New()
+		h.Write(seed[:])
+		h.Write(data)
+		return binary.BigEndian.Uint64(h.Sum(nil))
+	default:
+		h := fnv.New64a()
+		h.Write(seed[:])
+		h.Write(data)
+		return h.Sum64()
+	}
+}
+
+// Returns a deterministic hash of the value.  Struct fields are visited in
+// declaration order and map entries in the order of their encoded keys, so
+// the result does not change between runs or processes.  Floating point
+// tolerances are not supported since they cannot be hashed consistently.
+func Hash(v any, opts ...Option) uint64 {
+	c := newConfig(opts)
+	e := &encoder{config: c, visiting: map[visit]bool{}}
+	e.encode(reflect.ValueOf(v))
+	return c.sum(e.buf.Bytes())
+}
+
+// Markers written ahead of values whose encoding could otherwise be ambiguous
+const (
+	markerInvalid byte = iota
+	markerNil
+	markerValue
+	markerCycle
+)
+
+var (
+	hasherType = reflect.TypeOf((*Hasher)(nil)).Elem()
+	timeType   = reflect.TypeOf(time.Time{})
+)
+
+// Identifies a pointer being encoded.  The type is part of the key because a
+// pointer to a struct and a pointer to its first field share an address.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+type encoder struct {
+	*config
+	buf      bytes.Buffer
+	visiting map[visit]bool
+}
+
+func (e *encoder) writeUint(v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	e.buf.Write(b[:])
+}
+
+func (e *encoder) writeBytes(data []byte) {
+	e.writeUint(uint64(len(data)))
+	e.buf.Write(data)
+}
+
+func (e *encoder) writeFloat(f float64) {
+	if f == 0 {
+		// -0 and +0 are equal so they must hash the same
+		f = 0
+	}
+	e.writeUint(math.Float64bits(f))
+}
+
+// Encodes the value with a fresh buffer so that it can be sorted independently
+func (e *encoder) encodeSeparately(v reflect.Value) []byte {
+	child := &encoder{config: e.config, visiting: e.visiting}
+	child.encode(v)
+	return child.buf.Bytes()
+}
+
+func (e *encoder) encode(v reflect.Value) {
+	if !v.IsValid() {
+		e.buf.WriteByte(markerInvalid)
+		return
+	}
+
+	if v.Type() == timeType && v.CanInterface() {
+		t := v.Interface().(time.Time)
+		e.writeUint(uint64(t.Unix()))
+		e.writeUint(uint64(t.Nanosecond()))
+		return
+	}
+	if v.Type().Implements(hasherType) && v.CanInterface() {
+		if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+			e.buf.WriteByte(markerNil)
+			return
+		}
+		e.writeUint(v.Interface().(Hasher).HashCode())
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			e.buf.WriteByte(1)
+		} else {
+			e.buf.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		e.writeFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		e.writeFloat(real(v.Complex()))
+		e.writeFloat(imag(v.Complex()))
+	case reflect.String:
+		e.writeBytes([]byte(v.String()))
+	case reflect.Chan, reflect.UnsafePointer, reflect.Func:
+		if v.IsNil() {
+			e.buf.WriteByte(markerNil)
+		} else {
+			e.buf.WriteByte(markerValue)
+			e.writeUint(uint64(v.Pointer()))
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			e.buf.WriteByte(markerNil)
+			return
+		}
+		e.buf.WriteByte(markerValue)
+		e.encode(v.Elem())
+	case reflect.Pointer:
+		if v.IsNil() {
+			e.buf.WriteByte(markerNil)
+			return
+		}
+		key := visit{ptr: v.Pointer(), typ: v.Type()}
+		if e.visiting[key] {
+			e.buf.WriteByte(markerCycle)
+			return
+		}
+		e.visiting[key] = true
+		e.buf.WriteByte(markerValue)
+		e.encode(v.Elem())
+		delete(e.visiting, key)
+	case reflect.Slice:
+		if v.IsNil() && !e.nilEqualsEmpty {
+			e.buf.WriteByte(markerNil)
+			return
+		}
+		e.buf.WriteByte(markerValue)
+		e.encodeSequence(v)
+	case reflect.Array:
+		e.encodeSequence(v)
+	case reflect.Map:
+		if v.IsNil() && !e.nilEqualsEmpty {
+			e.buf.WriteByte(markerNil)
+			return
+		}
+		e.buf.WriteByte(markerValue)
+		e.encodeMap(v)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if e.ignored(v.Type().Field(i)) {
+				continue
+			}
+			e.encode(v.Field(i))
+		}
+	}
+}
+
+func (e *encoder) encodeSequence(v reflect.Value) {
+	e.writeUint(uint64(v.Len()))
+	if !e.unorderedSlices {
+		for i := 0; i < v.Len(); i++ {
+			e.encode(v.Index(i))
+		}
+		return
+	}
+
+	elements := make([][]byte, v.Len())
+	for i := range elements {
+		elements[i] = e.encodeSeparately(v.Index(i))
+	}
+	sort.Slice(elements, func(i, j int) bool {
+		return bytes.Compare(elements[i], elements[j]) < 0
+	})
+	for _, element := range elements {
+		e.writeBytes(element)
+	}
+}
+
+func (e *encoder) encodeMap(v reflect.Value) {
+	type entry struct {
+		key, value []byte
+	}
+
+	entries := make([]entry, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		entries = append(entries, entry{
+			key:   e.encodeSeparately(iter.Key()),
+			value: e.encodeSeparately(iter.Value()),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+
+	e.writeUint(uint64(len(entries)))
+	for _, entry := range entries {
+		e.writeBytes(entry.key)
+		e.writeBytes(entry.value)
+	}
+}
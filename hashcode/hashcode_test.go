@@ -0,0 +1,188 @@
+package hashcode
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jwmajors81/golang-commons-lang/equality"
+	"github.com/stretchr/testify/assert"
+)
+
+type Address struct {
+	Street string
+	City   string
+}
+
+type Person struct {
+	Name     string
+	Age      int
+	Updated  time.Time `hash:"-" equality:"-"`
+	Tags     []string
+	Address  *Address
+	Scores   map[string]float64
+	internal int
+}
+
+type Node struct {
+	Value int
+	Next  *Node
+}
+
+type caseInsensitive string
+
+func (c caseInsensitive) HashCode() uint64 {
+	return uint64(len(c))
+}
+
+func TestXXHash64(t *testing.T) {
+	assert.Equal(t, uint64(0xef46db3751d8e999), xxhash64([]byte(""), 0))
+	assert.Equal(t, uint64(0xd24ec4f1a98c6e5b), xxhash64([]byte("a"), 0))
+	assert.Equal(t, uint64(0x44bc2cf5ad770999), xxhash64([]byte("abc"), 0))
+	assert.Equal(t, uint64(0xfbcea83c8a378bf1), xxhash64([]byte("Nobody inspects the spammish repetition"), 0))
+}
+
+func TestHashIsStable(t *testing.T) {
+	p := Person{Name: "sam", Age: 3, Tags: []string{"a"}, Scores: map[string]float64{"a": 1, "b": 2, "c": 3}}
+
+	for _, algorithm := range []Algorithm{FNV1a, XXHash, SHA256} {
+		expected := Hash(p, WithAlgorithm(algorithm))
+		for i := 0; i < 20; i++ {
+			assert.Equal(t, expected, Hash(p, WithAlgorithm(algorithm)))
+		}
+	}
+
+	assert.NotEqual(t, Hash(p, WithAlgorithm(FNV1a)), Hash(p, WithAlgorithm(XXHash)))
+	assert.NotEqual(t, Hash(p, WithAlgorithm(FNV1a)), Hash(p, WithAlgorithm(SHA256)))
+}
+
+func TestHashSeed(t *testing.T) {
+	for _, algorithm := range []Algorithm{FNV1a, XXHash, SHA256} {
+		assert.NotEqual(t, Hash("abc", WithAlgorithm(algorithm)), Hash("abc", WithAlgorithm(algorithm), WithSeed(42)))
+		assert.Equal(t, Hash("abc", WithAlgorithm(algorithm), WithSeed(42)), Hash("abc", WithAlgorithm(algorithm), WithSeed(42)))
+	}
+}
+
+func TestHashDistinguishesValues(t *testing.T) {
+	assert.NotEqual(t, Hash([]string{"ab", "c"}), Hash([]string{"a", "bc"}))
+	assert.NotEqual(t, Hash([]int{1, 2}), Hash([]int{2, 1}))
+	assert.NotEqual(t, Hash(Person{Name: "a"}), Hash(Person{Name: "b"}))
+	assert.NotEqual(t, Hash([]int(nil)), Hash([]int{}))
+	assert.NotEqual(t, Hash((*Address)(nil)), Hash(&Address{}))
+	assert.NotEqual(t, Hash(Person{internal: 1}), Hash(Person{internal: 2}))
+}
+
+func TestHashMatchesEquality(t *testing.T) {
+	now := time.Now()
+	var tests = map[string]struct {
+		a, b     any
+		eqOpts   []equality.Option
+		hashOpts []Option
+	}{
+		"identical structs": {
+			a: Person{Name: "sam", Address: &Address{City: "x"}},
+			b: Person{Name: "sam", Address: &Address{City: "x"}},
+		},
+		"maps built in different orders": {
+			a: map[string]int{"a": 1, "b": 2, "c": 3, "d": 4},
+			b: map[string]int{"d": 4, "c": 3, "b": 2, "a": 1},
+		},
+		"ignored tag": {
+			a:        Person{Name: "sam", Updated: now},
+			b:        Person{Name: "sam", Updated: now.Add(time.Hour)},
+			eqOpts:   []equality.Option{equality.IgnoreTag("equality")},
+			hashOpts: []Option{IgnoreTag("hash")},
+		},
+		"ignored fields": {
+			a:        Person{Name: "sam", Age: 1, internal: 1},
+			b:        Person{Name: "sam", Age: 2, internal: 2},
+			eqOpts:   []equality.Option{equality.IgnoreFields("Age", "internal")},
+			hashOpts: []Option{IgnoreFields("Age", "internal")},
+		},
+		"nil equals empty": {
+			a:        Person{Tags: nil, Scores: nil},
+			b:        Person{Tags: []string{}, Scores: map[string]float64{}},
+			eqOpts:   []equality.Option{equality.NilEqualsEmpty()},
+			hashOpts: []Option{NilEqualsEmpty()},
+		},
+		"unordered slices": {
+			a:        []string{"a", "b", "b"},
+			b:        []string{"b", "a", "b"},
+			eqOpts:   []equality.Option{equality.UnorderedSlices()},
+			hashOpts: []Option{UnorderedSlices()},
+		},
+		"times in different zones": {
+			a: time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC),
+			b: time.Date(2022, 1, 1, 7, 0, 0, 0, time.FixedZone("EST", -5*60*60)),
+		},
+		"negative zero": {
+			a: 0.0,
+			b: math.Copysign(0, -1),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.True(t, equality.DeepEqual(test.a, test.b, test.eqOpts...))
+			for _, algorithm := range []Algorithm{FNV1a, XXHash, SHA256} {
+				opts := append([]Option{WithAlgorithm(algorithm)}, test.hashOpts...)
+				assert.Equal(t, Hash(test.a, opts...), Hash(test.b, opts...))
+			}
+		})
+	}
+}
+
+func TestHashCycles(t *testing.T) {
+	a := &Node{Value: 1}
+	a.Next = &Node{Value: 2, Next: a}
+	b := &Node{Value: 1}
+	b.Next = &Node{Value: 2, Next: b}
+
+	assert.Equal(t, Hash(a), Hash(b))
+
+	b.Next.Value = 3
+	assert.NotEqual(t, Hash(a), Hash(b))
+}
+
+func TestHashPointerToFirstField(t *testing.T) {
+	type counter struct {
+		Value int
+		First *int
+	}
+
+	// the pointer to the first field has the address of the struct that is
+	// being encoded, which is not a cycle
+	shared := &counter{Value: 1}
+	shared.First = &shared.Value
+	one := 1
+	separate := &counter{Value: 1, First: &one}
+	assert.Equal(t, Hash(separate), Hash(shared))
+}
+
+func TestHashUsesHasher(t *testing.T) {
+	assert.Equal(t, Hash(caseInsensitive("ABC")), Hash(caseInsensitive("abc")))
+	assert.Equal(t, Hash([]caseInsensitive{"ABC"}), Hash([]caseInsensitive{"abc"}))
+
+	type holder struct {
+		H Hasher
+	}
+	assert.NotPanics(t, func() { Hash(holder{}) })
+	assert.Equal(t, Hash(holder{}), Hash(holder{}))
+	assert.NotEqual(t, Hash(holder{}), Hash(holder{H: caseInsensitive("")}))
+}
+
+func TestHashCodeBuilder(t *testing.T) {
+	a := Person{Name: "sam", Age: 3, Tags: []string{"x"}}
+	b := Person{Name: "sam", Age: 3, Tags: []string{"x"}, internal: 5}
+
+	hashA := NewHashCodeBuilder().Append(a.Name).Append(a.Age).Append(a.Tags).HashCode()
+	hashB := NewHashCodeBuilder().Append(b.Name).Append(b.Age).Append(b.Tags).HashCode()
+	assert.Equal(t, hashA, hashB)
+
+	assert.NotEqual(t, hashA, NewHashCodeBuilder().Append(a.Name).Append(a.Age).HashCode())
+	assert.NotEqual(t, hashA, NewHashCodeBuilder().Append(a.Age).Append(a.Name).Append(a.Tags).HashCode())
+	assert.NotEqual(t, hashA, NewHashCodeBuilder(WithSeed(1)).Append(a.Name).Append(a.Age).Append(a.Tags).HashCode())
+
+	withSuper := NewHashCodeBuilder().AppendSuper(hashA).Append("extra").HashCode()
+	assert.Equal(t, withSuper, NewHashCodeBuilder().AppendSuper(hashB).Append("extra").HashCode())
+}
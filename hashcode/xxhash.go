@@ -0,0 +1,78 @@
+package hashcode
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+const (
+	prime64v1 uint64 = 11400714785074694791
+	prime64v2 uint64 = 14029467366897019727
+	prime64v3 uint64 = 1609587929392839161
+	prime64v4 uint64 = 9650029242287828579
+	prime64v5 uint64 = 2870177450012600261
+)
+
+// Computes the 64 bit xxHash (XXH64) of the data using the seed provided
+func xxhash64(data []byte, seed uint64) uint64 {
+	n := len(data)
+	var h uint64
+
+	if n >= 32 {
+		v1 := seed + prime64v1 + prime64v2
+		v2 := seed + prime64v2
+		v3 := seed
+		v4 := seed - prime64v1
+		for len(data) >= 32 {
+			v1 = xxRound(v1, binary.LittleEndian.Uint64(data[0:8]))
+			v2 = xxRound(v2, binary.LittleEndian.Uint64(data[8:16]))
+			v3 = xxRound(v3, binary.LittleEndian.Uint64(data[16:24]))
+			v4 = xxRound(v4, binary.LittleEndian.Uint64(data[24:32]))
+			data = data[32:]
+		}
+		h = bits.RotateLeft64(v1, 1) + bits.RotateLeft64(v2, 7) + bits.RotateLeft64(v3, 12) + bits.RotateLeft64(v4, 18)
+		h = xxMergeRound(h, v1)
+		h = xxMergeRound(h, v2)
+		h = xxMergeRound(h, v3)
+		h = xxMergeRound(h, v4)
+	} else {
+		h = seed + prime64v5
+	}
+
+	h += uint64(n)
+
+	for len(data) >= 8 {
+		h ^= xxRound(0, binary.LittleEndian.Uint64(data[:8]))
+		h = bits.RotateLeft64(h, 27)*prime64v1 + prime64v4
+		data = data[8:]
+	}
+	if len(data) >= 4 {
+		h ^= uint64(binary.LittleEndian.Uint32(data[:4])) * prime64v1
+		h = bits.RotateLeft64(h, 23)*prime64v2 + prime64v3
+		data = data[4:]
+	}
+	for _, b := range data {
+		h ^= uint64(b) * prime64v5
+		h = bits.RotateLeft64(h, 11) * prime64v1
+	}
+
+	h ^= h >> 33
+	h *= prime64v2
+	h ^= h >> 29
+	h *= prime64v3
+	h ^= h >> 32
+
+	return h
+}
+
+func xxRound(acc, input uint64) uint64 {
+	acc += input * prime64v2
+	acc = bits.RotateLeft64(acc, 31)
+	return acc * prime64v1
+}
+
+func xxMergeRound(acc, val uint64) uint64 {
+	val = xxRound(0, val)
+	acc ^= val
+	return acc*prime64v1 + prime64v4
+}
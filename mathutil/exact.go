@@ -0,0 +1,157 @@
+package mathutil
+
+import (
+	"fmt"
+
+	"github.com/jwmajors81/golang-commons-lang/numbers"
+	"golang.org/x/exp/constraints"
+)
+
+var (
+	// Returned, wrapped with the operation, when a result does not fit in its
+	// type.  It is the same error as numbers.ErrOverflow.
+	ErrOverflow = numbers.ErrOverflow
+	// Returned when dividing by zero.  It is the same error as
+	// numbers.ErrDivideByZero.
+	ErrDivideByZero = numbers.ErrDivideByZero
+)
+
+// Returns whether the integer type is signed
+func isSigned[T constraints.Integer]() bool {
+	return ^T(0) < 0
+}
+
+// Returns the largest value of the integer type, such as 127 for int8
+func MaxValue[T constraints.Integer]() T {
+	if !isSigned[T]() {
+		return ^T(0)
+	}
+	max := T(1)
+	for max<<1|1 > max {
+		max = max<<1 | 1
+	}
+	return max
+}
+
+// Returns the smallest value of the integer type, such as -128 for int8 or 0
+// for unsigned types
+func MinValue[T constraints.Integer]() T {
+	if !isSigned[T]() {
+		return 0
+	}
+	return -MaxValue[T]() - 1
+}
+
+func overflow[T constraints.Integer](operation string, a, b T) error {
+	return fmt.Errorf("%w: %v %s %v overflows %T", ErrOverflow, a, operation, b, a)
+}
+
+// Returns a + b, or an error wrapping ErrOverflow when the sum does not fit
+func AddExact[T constraints.Integer](a, b T) (T, error) {
+	result := a + b
+	if (b >= 0 && result < a) || (b < 0 && result > a) {
+		return 0, overflow("+", a, b)
+	}
+	return result, nil
+}
+
+// Returns a - b, or an error wrapping ErrOverflow when the difference does
+// not fit
+func SubExact[T constraints.Integer](a, b T) (T, error) {
+	result := a - b
+	if (b >= 0 && result > a) || (b < 0 && result < a) {
+		return 0, overflow("-", a, b)
+	}
+	return result, nil
+}
+
+// Returns a * b, or an error wrapping ErrOverflow when the product does not
+// fit
+func MulExact[T constraints.Integer](a, b T) (T, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	result := a * b
+	// The minimum value divided by -1 overflows back to itself, so that case
+	// has to be checked separately
+	if result/b != a || (isSigned[T]() && b == ^T(0) && a == MinValue[T]()) {
+		return 0, overflow("*", a, b)
+	}
+	return result, nil
+}
+
+// Returns -a, or an error wrapping ErrOverflow for the minimum value of a
+// signed type or any value other than zero of an unsigned type
+func NegateExact[T constraints.Integer](a T) (T, error) {
+	if (isSigned[T]() && a == MinValue[T]()) || (!isSigned[T]() && a != 0) {
+		return 0, fmt.Errorf("%w: -%v overflows %T", ErrOverflow, a, a)
+	}
+	return -a, nil
+}
+
+// Returns the absolute value of a, or an error wrapping ErrOverflow for the
+// minimum value of a signed type
+func AbsExact[T constraints.Integer](a T) (T, error) {
+	if a < 0 {
+		return NegateExact(a)
+	}
+	return a, nil
+}
+
+// Converts the value to an int, or returns an error wrapping ErrOverflow when
+// it does not fit.  Use numbers.Convert to convert to other integer types.
+func ToIntExact[T constraints.Integer](value T) (int, error) {
+	return numbers.Convert[int](value)
+}
+
+// Returns a + b, or the maximum or minimum value of the type when the sum
+// overflows
+func SaturatedAdd[T constraints.Integer](a, b T) T {
+	result, err := AddExact(a, b)
+	if err == nil {
+		return result
+	}
+	if b > 0 {
+		return MaxValue[T]()
+	}
+	return MinValue[T]()
+}
+
+// Returns a - b, or the maximum or minimum value of the type when the
+// difference overflows
+func SaturatedSub[T constraints.Integer](a, b T) T {
+	result, err := SubExact(a, b)
+	if err == nil {
+		return result
+	}
+	if b > 0 {
+		return MinValue[T]()
+	}
+	return MaxValue[T]()
+}
+
+// Returns a * b, or the maximum or minimum value of the type when the
+// product overflows
+func SaturatedMul[T constraints.Integer](a, b T) T {
+	result, err := MulExact(a, b)
+	if err == nil {
+		return result
+	}
+	if (a < 0) != (b < 0) {
+		return MinValue[T]()
+	}
+	return MaxValue[T]()
+}
+
+// Returns -a, or the maximum value of a signed type for its minimum value.
+// Unsigned values other than zero saturate to zero.
+func SaturatedNegate[T constraints.Integer](a T) T {
+	result, err := NegateExact(a)
+	if err == nil {
+		return result
+	}
+	if isSigned[T]() {
+		return MaxValue[T]()
+	}
+	return 0
+}
@@ -0,0 +1,96 @@
+package mathutil
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jwmajors81/golang-commons-lang/numbers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimits(t *testing.T) {
+	assert.Equal(t, int8(math.MaxInt8), MaxValue[int8]())
+	assert.Equal(t, int8(math.MinInt8), MinValue[int8]())
+	assert.Equal(t, int64(math.MaxInt64), MaxValue[int64]())
+	assert.Equal(t, int64(math.MinInt64), MinValue[int64]())
+	assert.Equal(t, uint16(math.MaxUint16), MaxValue[uint16]())
+	assert.Equal(t, uint16(0), MinValue[uint16]())
+	assert.Equal(t, uint64(math.MaxUint64), MaxValue[uint64]())
+}
+
+func TestExact(t *testing.T) {
+	var tests = map[string]struct {
+		result          func() (int64, error)
+		expected        int64
+		expectedMessage string
+	}{
+		"add":                {result: func() (int64, error) { r, err := AddExact[int8](100, 27); return int64(r), err }, expected: 127},
+		"add negative":       {result: func() (int64, error) { r, err := AddExact[int8](-100, -28); return int64(r), err }, expected: -128},
+		"add overflow":       {result: func() (int64, error) { r, err := AddExact[int8](100, 28); return int64(r), err }, expectedMessage: "value out of range: 100 + 28 overflows int8"},
+		"add underflow":      {result: func() (int64, error) { r, err := AddExact[int8](-100, -29); return int64(r), err }, expectedMessage: "value out of range: -100 + -29 overflows int8"},
+		"add unsigned":       {result: func() (int64, error) { r, err := AddExact[uint8](200, 56); return int64(r), err }, expectedMessage: "value out of range: 200 + 56 overflows uint8"},
+		"sub":                {result: func() (int64, error) { r, err := SubExact[int8](-100, 28); return int64(r), err }, expected: -128},
+		"sub overflow":       {result: func() (int64, error) { r, err := SubExact[int8](0, -128); return int64(r), err }, expectedMessage: "value out of range: 0 - -128 overflows int8"},
+		"sub unsigned":       {result: func() (int64, error) { r, err := SubExact[uint](1, 2); return int64(r), err }, expectedMessage: "value out of range: 1 - 2 overflows uint"},
+		"mul":                {result: func() (int64, error) { r, err := MulExact[int8](-16, 8); return int64(r), err }, expected: -128},
+		"mul by zero":        {result: func() (int64, error) { r, err := MulExact[int8](0, -128); return int64(r), err }, expected: 0},
+		"mul overflow":       {result: func() (int64, error) { r, err := MulExact[int8](16, 8); return int64(r), err }, expectedMessage: "value out of range: 16 * 8 overflows int8"},
+		"mul minimum by -1":  {result: func() (int64, error) { r, err := MulExact[int8](-128, -1); return int64(r), err }, expectedMessage: "value out of range: -128 * -1 overflows int8"},
+		"mul -1 by minimum":  {result: func() (int64, error) { r, err := MulExact[int8](-1, -128); return int64(r), err }, expectedMessage: "value out of range: -1 * -128 overflows int8"},
+		"mul int64":          {result: func() (int64, error) { return MulExact[int64](math.MaxInt64, 2) }, expectedMessage: "value out of range: 9223372036854775807 * 2 overflows int64"},
+		"mul unsigned":       {result: func() (int64, error) { r, err := MulExact[uint8](16, 16); return int64(r), err }, expectedMessage: "value out of range: 16 * 16 overflows uint8"},
+		"negate":             {result: func() (int64, error) { r, err := NegateExact[int8](-127); return int64(r), err }, expected: 127},
+		"negate minimum":     {result: func() (int64, error) { r, err := NegateExact[int8](-128); return int64(r), err }, expectedMessage: "value out of range: --128 overflows int8"},
+		"negate unsigned":    {result: func() (int64, error) { r, err := NegateExact[uint8](1); return int64(r), err }, expectedMessage: "value out of range: -1 overflows uint8"},
+		"negate zero":        {result: func() (int64, error) { r, err := NegateExact[uint8](0); return int64(r), err }, expected: 0},
+		"abs":                {result: func() (int64, error) { r, err := AbsExact[int8](-5); return int64(r), err }, expected: 5},
+		"abs minimum":        {result: func() (int64, error) { r, err := AbsExact[int8](-128); return int64(r), err }, expectedMessage: "value out of range: --128 overflows int8"},
+		"to int":             {result: func() (int64, error) { r, err := ToIntExact(uint64(42)); return int64(r), err }, expected: 42},
+		"to int of negative": {result: func() (int64, error) { r, err := ToIntExact(int8(-42)); return int64(r), err }, expected: -42},
+		"to int overflow":    {result: func() (int64, error) { r, err := ToIntExact(uint64(math.MaxUint64)); return int64(r), err }, expectedMessage: "value out of range: 18446744073709551615 does not fit in int"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := test.result()
+			if test.expectedMessage != "" {
+				assert.EqualError(t, err, test.expectedMessage)
+				assert.ErrorIs(t, err, ErrOverflow)
+				assert.ErrorIs(t, err, numbers.ErrOverflow)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestSaturated(t *testing.T) {
+	var tests = map[string]struct {
+		actual   int64
+		expected int64
+	}{
+		"add":                     {actual: int64(SaturatedAdd[int8](100, 20)), expected: 120},
+		"add above maximum":       {actual: int64(SaturatedAdd[int8](100, 100)), expected: 127},
+		"add below minimum":       {actual: int64(SaturatedAdd[int8](-100, -100)), expected: -128},
+		"add unsigned":            {actual: int64(SaturatedAdd[uint8](200, 100)), expected: 255},
+		"sub below minimum":       {actual: int64(SaturatedSub[int8](-100, 100)), expected: -128},
+		"sub above maximum":       {actual: int64(SaturatedSub[int8](100, -100)), expected: 127},
+		"sub unsigned":            {actual: int64(SaturatedSub[uint8](1, 2)), expected: 0},
+		"mul":                     {actual: int64(SaturatedMul[int8](-4, 5)), expected: -20},
+		"mul above maximum":       {actual: int64(SaturatedMul[int8](-64, -64)), expected: 127},
+		"mul below minimum":       {actual: int64(SaturatedMul[int8](64, -64)), expected: -128},
+		"mul minimum by -1":       {actual: int64(SaturatedMul[int8](-128, -1)), expected: 127},
+		"mul unsigned":            {actual: int64(SaturatedMul[uint8](16, 16)), expected: 255},
+		"negate":                  {actual: int64(SaturatedNegate[int8](5)), expected: -5},
+		"negate minimum":          {actual: int64(SaturatedNegate[int8](-128)), expected: 127},
+		"negate unsigned":         {actual: int64(SaturatedNegate[uint8](5)), expected: 0},
+		"int64 add above maximum": {actual: SaturatedAdd[int64](math.MaxInt64, 1), expected: math.MaxInt64},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.actual)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package mathutil
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Returns the largest integer less than or equal to x / y, rounding towards
+// negative infinity instead of towards zero like the / operator.  Returns an
+// error wrapping ErrDivideByZero when y is zero or ErrOverflow when dividing
+// the minimum value of a signed type by -1.
+//
+//	FloorDiv(7, 2)   // 3
+//	FloorDiv(-7, 2)  // -4, where -7 / 2 is -3
+func FloorDiv[T constraints.Integer](x, y T) (T, error) {
+	if y == 0 {
+		return 0, fmt.Errorf("%w: %v / 0", ErrDivideByZero, x)
+	}
+	if isSigned[T]() && y == ^T(0) && x == MinValue[T]() {
+		return 0, overflow("/", x, y)
+	}
+	quotient := x / y
+	if x%y != 0 && (x < 0) != (y < 0) {
+		quotient--
+	}
+	return quotient, nil
+}
+
+// Returns the floor modulus of x and y, which has the sign of y instead of
+// the sign of x like the % operator, so that
+// FloorDiv(x, y) * y + FloorMod(x, y) == x.  Returns an error wrapping
+// ErrDivideByZero when y is zero.
+//
+//	FloorMod(7, 3)   // 1
+//	FloorMod(-7, 3)  // 2, where -7 % 3 is -1
+func FloorMod[T constraints.Integer](x, y T) (T, error) {
+	if y == 0 {
+		return 0, fmt.Errorf("%w: %v %% 0", ErrDivideByZero, x)
+	}
+	modulus := x % y
+	if modulus != 0 && (modulus < 0) != (y < 0) {
+		modulus += y
+	}
+	return modulus, nil
+}
+
+// Returns the greatest common divisor of the absolute values of a and b.
+// GCD(0, 0) is 0.  Returns an error wrapping ErrOverflow when the result is
+// the absolute value of the minimum value of a signed type, such as
+// GCD(math.MinInt64, 0).
+func GCD[T constraints.Integer](a, b T) (T, error) {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		result, err := NegateExact(a)
+		if err != nil {
+			return 0, fmt.Errorf("%w: the greatest common divisor is %v", ErrOverflow, a)
+		}
+		return result, nil
+	}
+	return a, nil
+}
+
+// Returns the least common multiple of the absolute values of a and b, or 0
+// when either is 0.  Returns an error wrapping ErrOverflow when the result
+// does not fit.
+func LCM[T constraints.Integer](a, b T) (T, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	divisor, err := GCD(a, b)
+	if err != nil {
+		return 0, err
+	}
+	absA, errA := AbsExact(a / divisor)
+	absB, errB := AbsExact(b)
+	if errA != nil || errB != nil {
+		return 0, fmt.Errorf("%w: the least common multiple of %v and %v overflows %T", ErrOverflow, a, b, a)
+	}
+	result, err := MulExact(absA, absB)
+	if err != nil {
+		return 0, fmt.Errorf("%w: the least common multiple of %v and %v overflows %T", ErrOverflow, a, b, a)
+	}
+	return result, nil
+}
+
+// Returns base raised to the exponent, or an error wrapping ErrOverflow when
+// the result does not fit.  Pow(0, 0) is 1.
+func Pow[T constraints.Integer](base T, exponent uint) (T, error) {
+	result, square := T(1), base
+	for remaining := exponent; remaining > 0; remaining >>= 1 {
+		var err error
+		if remaining&1 == 1 {
+			result, err = MulExact(result, square)
+		}
+		if err == nil && remaining > 1 {
+			square, err = MulExact(square, square)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v ^ %d overflows %T", ErrOverflow, base, exponent, base)
+		}
+	}
+	return result, nil
+}
+
+// Returns the value limited to the range min to max inclusive.  The result
+// is min when min is greater than max.
+func Clamp[T constraints.Ordered](value, min, max T) T {
+	if value > max {
+		value = max
+	}
+	if value < min {
+		value = min
+	}
+	return value
+}
@@ -0,0 +1,151 @@
+package mathutil
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFloorDivAndMod(t *testing.T) {
+	var tests = map[string]struct {
+		x, y        int
+		expectedDiv int
+		expectedMod int
+	}{
+		"positive":          {x: 7, y: 2, expectedDiv: 3, expectedMod: 1},
+		"negative dividend": {x: -7, y: 2, expectedDiv: -4, expectedMod: 1},
+		"negative divisor":  {x: 7, y: -2, expectedDiv: -4, expectedMod: -1},
+		"both negative":     {x: -7, y: -2, expectedDiv: 3, expectedMod: -1},
+		"exact":             {x: -6, y: 3, expectedDiv: -2, expectedMod: 0},
+		"zero dividend":     {x: 0, y: -3, expectedDiv: 0, expectedMod: 0},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			quotient, err := FloorDiv(test.x, test.y)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedDiv, quotient)
+
+			modulus, err := FloorMod(test.x, test.y)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedMod, modulus)
+			assert.Equal(t, test.x, quotient*test.y+modulus)
+		})
+	}
+}
+
+func TestFloorDivAndModErrors(t *testing.T) {
+	_, err := FloorDiv(1, 0)
+	assert.EqualError(t, err, "division by zero: 1 / 0")
+	assert.ErrorIs(t, err, ErrDivideByZero)
+
+	_, err = FloorMod(1, 0)
+	assert.EqualError(t, err, "division by zero: 1 % 0")
+	assert.ErrorIs(t, err, ErrDivideByZero)
+
+	_, err = FloorDiv[int8](-128, -1)
+	assert.EqualError(t, err, "value out of range: -128 / -1 overflows int8")
+
+	modulus, err := FloorMod[int8](-128, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, int8(0), modulus)
+
+	unsigned, err := FloorDiv[uint](7, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(3), unsigned)
+}
+
+func TestGCDAndLCM(t *testing.T) {
+	var tests = map[string]struct {
+		a, b        int64
+		expectedGCD int64
+		expectedLCM int64
+	}{
+		"positive":      {a: 12, b: 18, expectedGCD: 6, expectedLCM: 36},
+		"negative":      {a: -12, b: 18, expectedGCD: 6, expectedLCM: 36},
+		"both negative": {a: -4, b: -6, expectedGCD: 2, expectedLCM: 12},
+		"coprime":       {a: 7, b: 9, expectedGCD: 1, expectedLCM: 63},
+		"zero":          {a: 0, b: 5, expectedGCD: 5, expectedLCM: 0},
+		"both zero":     {a: 0, b: 0, expectedGCD: 0, expectedLCM: 0},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gcd, err := GCD(test.a, test.b)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedGCD, gcd)
+
+			lcm, err := LCM(test.a, test.b)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedLCM, lcm)
+		})
+	}
+}
+
+func TestGCDAndLCMOverflow(t *testing.T) {
+	_, err := GCD[int64](math.MinInt64, 0)
+	assert.EqualError(t, err, "value out of range: the greatest common divisor is -9223372036854775808")
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	gcd, err := GCD[int64](math.MinInt64, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), gcd)
+	_, err = LCM[int64](math.MinInt64, 2)
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	_, err = LCM[int8](64, 3)
+	assert.EqualError(t, err, "value out of range: the least common multiple of 64 and 3 overflows int8")
+
+	unsigned, err := LCM[uint8](16, 12)
+	assert.Nil(t, err)
+	assert.Equal(t, uint8(48), unsigned)
+}
+
+func TestPow(t *testing.T) {
+	var tests = map[string]struct {
+		base     int64
+		exponent uint
+		expected int64
+	}{
+		"square":           {base: 3, exponent: 2, expected: 9},
+		"zero exponent":    {base: 7, exponent: 0, expected: 1},
+		"zero to zero":     {base: 0, exponent: 0, expected: 1},
+		"negative odd":     {base: -2, exponent: 3, expected: -8},
+		"negative even":    {base: -2, exponent: 4, expected: 16},
+		"minus one":        {base: -1, exponent: 1001, expected: -1},
+		"largest power":    {base: 2, exponent: 62, expected: 1 << 62},
+		"minimum":          {base: -2, exponent: 63, expected: math.MinInt64},
+		"one to any power": {base: 1, exponent: math.MaxUint32, expected: 1},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := Pow(test.base, test.exponent)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestPowOverflow(t *testing.T) {
+	_, err := Pow[int64](2, 63)
+	assert.EqualError(t, err, "value out of range: 2 ^ 63 overflows int64")
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	_, err = Pow[int8](3, 5)
+	assert.EqualError(t, err, "value out of range: 3 ^ 5 overflows int8")
+
+	_, err = Pow[uint8](2, 8)
+	assert.ErrorIs(t, err, ErrOverflow)
+}
+
+func TestClamp(t *testing.T) {
+	assert.Equal(t, 5, Clamp(5, 1, 10))
+	assert.Equal(t, 1, Clamp(-5, 1, 10))
+	assert.Equal(t, 10, Clamp(50, 1, 10))
+	assert.Equal(t, uint8(255), Clamp[uint8](255, 0, 255))
+	assert.Equal(t, 0.5, Clamp(0.7, 0.0, 0.5))
+	assert.Equal(t, "m", Clamp("z", "a", "m"))
+	assert.Equal(t, 3, Clamp(2, 3, 1))
+}
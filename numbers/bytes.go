@@ -0,0 +1,131 @@
+package numbers
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// The system of units used to format byte sizes
+type ByteUnits int
+
+const (
+	// Decimal units that are powers of 1000: kB, MB, GB, ...
+	SI ByteUnits = iota
+	// Binary units that are powers of 1024: KiB, MiB, GiB, ...
+	IEC
+)
+
+var (
+	siByteUnits  = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+	iecByteUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+)
+
+// The multipliers of the units ParseBytes accepts, keyed by their lower case
+// name.  Units without the "i" of IEC units, including single letters, are SI.
+var byteMultipliers = map[string]uint64{
+	"": 1, "b": 1, "byte": 1, "bytes": 1,
+	"k": 1e3, "kb": 1e3, "ki": 1 << 10, "kib": 1 << 10,
+	"m": 1e6, "mb": 1e6, "mi": 1 << 20, "mib": 1 << 20,
+	"g": 1e9, "gb": 1e9, "gi": 1 << 30, "gib": 1 << 30,
+	"t": 1e12, "tb": 1e12, "ti": 1 << 40, "tib": 1 << 40,
+	"p": 1e15, "pb": 1e15, "pi": 1 << 50, "pib": 1 << 50,
+	"e": 1e18, "eb": 1e18, "ei": 1 << 60, "eib": 1 << 60,
+}
+
+// Formats a number of bytes in the largest unit that keeps the value at
+// least 1, with at most precision decimal places.  Trailing zeros are
+// removed and sizes below one kilobyte are written in bytes.
+//
+//	FormatBytes(1500, SI, 1)          // "1.5 kB"
+//	FormatBytes(1536, IEC, 2)         // "1.5 KiB"
+//	FormatBytes(1073741824, IEC, 1)   // "1 GiB"
+//	FormatBytes(999, SI, 1)           // "999 B"
+func FormatBytes(size int64, units ByteUnits, precision int) string {
+	base, names := 1000.0, siByteUnits
+	if units == IEC {
+		base, names = 1024.0, iecByteUnits
+	}
+
+	sign, magnitude := "", uint64(size)
+	if size < 0 {
+		sign, magnitude = "-", uint64(-size)
+	}
+	if float64(magnitude) < base {
+		return fmt.Sprintf("%s%d B", sign, magnitude)
+	}
+
+	text, unit := scale(float64(magnitude), base, len(names)-1, precision)
+	return sign + text + " " + names[unit]
+}
+
+// Divides the value by the base until it is below the base or the largest
+// unit is reached, returning the formatted value and the number of divisions
+func scale(value float64, base float64, largest int, precision int) (string, int) {
+	unit := 0
+	for value >= base && unit < largest {
+		value /= base
+		unit++
+	}
+	text := formatDecimal(value, precision)
+	// Rounding may carry the value up to the base, as with 999999 becoming
+	// "1000 kB", in which case the next unit is used instead
+	if rounded, _ := strconv.ParseFloat(text, 64); rounded >= base && unit < largest {
+		text = formatDecimal(value/base, precision)
+		unit++
+	}
+	return text, unit
+}
+
+// Formats the value with at most precision decimal places
+func formatDecimal(value float64, precision int) string {
+	text := strconv.FormatFloat(value, 'f', precision, 64)
+	if strings.Contains(text, ".") {
+		text = strings.TrimRight(strings.TrimRight(text, "0"), ".")
+	}
+	return text
+}
+
+// Parses a byte size such as "512", "1.5GiB", "10 MB" or "2k".  Units are
+// case insensitive; units with an "i" such as KiB are powers of 1024 and all
+// others, including single letters, are powers of 1000.  Fractional bytes are
+// rounded to the nearest byte.  Returns an error wrapping ErrInvalidNumber
+// when the text is not a size or ErrOverflow when it does not fit in an
+// int64.
+func ParseBytes(value string) (int64, error) {
+	invalid := fmt.Errorf("%w: %q is not a byte size", ErrInvalidNumber, value)
+	text := strings.TrimSpace(value)
+	end := strings.IndexFunc(text, func(r rune) bool {
+		return !strings.ContainsRune("+-.0123456789", r)
+	})
+	if end < 0 {
+		end = len(text)
+	}
+	number, unit := text[:end], strings.TrimSpace(text[end:])
+
+	multiplier, known := byteMultipliers[strings.ToLower(unit)]
+	if !known || !IsParsable(strings.TrimPrefix(number, "+")) {
+		return 0, invalid
+	}
+	size, ok := new(big.Rat).SetString(number)
+	if !ok {
+		return 0, invalid
+	}
+	size.Mul(size, new(big.Rat).SetInt(new(big.Int).SetUint64(multiplier)))
+
+	bytes := roundRat(size)
+	if !bytes.IsInt64() {
+		return 0, fmt.Errorf("%w: %s does not fit in int64", ErrOverflow, value)
+	}
+	return bytes.Int64(), nil
+}
+
+// Returns the integer nearest to the rat, rounding halves away from zero
+func roundRat(value *big.Rat) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+	if remainder.Abs(remainder).Lsh(remainder, 1).Cmp(value.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(value.Sign())))
+	}
+	return quotient
+}
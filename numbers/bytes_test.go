@@ -0,0 +1,117 @@
+package numbers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatBytes(t *testing.T) {
+	var tests = map[string]struct {
+		size      int64
+		units     ByteUnits
+		precision int
+		expected  string
+	}{
+		"zero":              {size: 0, units: SI, precision: 1, expected: "0 B"},
+		"bytes":             {size: 999, units: SI, precision: 1, expected: "999 B"},
+		"binary bytes":      {size: 1023, units: IEC, precision: 1, expected: "1023 B"},
+		"kilobytes":         {size: 1500, units: SI, precision: 1, expected: "1.5 kB"},
+		"kibibytes":         {size: 1536, units: IEC, precision: 2, expected: "1.5 KiB"},
+		"trailing zeros":    {size: 1000, units: SI, precision: 3, expected: "1 kB"},
+		"precision":         {size: 1234567, units: SI, precision: 2, expected: "1.23 MB"},
+		"no decimals":       {size: 1234567, units: SI, precision: 0, expected: "1 MB"},
+		"gibibyte":          {size: 1 << 30, units: IEC, precision: 1, expected: "1 GiB"},
+		"rounds up to next": {size: 999999, units: SI, precision: 1, expected: "1 MB"},
+		"binary rounds up":  {size: 1048575, units: IEC, precision: 1, expected: "1 MiB"},
+		"negative":          {size: -1500, units: SI, precision: 1, expected: "-1.5 kB"},
+		"negative bytes":    {size: -5, units: IEC, precision: 1, expected: "-5 B"},
+		"largest":           {size: math.MaxInt64, units: IEC, precision: 2, expected: "8 EiB"},
+		"smallest":          {size: math.MinInt64, units: SI, precision: 2, expected: "-9.22 EB"},
+		"terabytes":         {size: 2500000000000, units: SI, precision: 1, expected: "2.5 TB"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, FormatBytes(test.size, test.units, test.precision))
+		})
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	var tests = map[string]struct {
+		input    string
+		expected int64
+	}{
+		"bytes":              {input: "512", expected: 512},
+		"bytes unit":         {input: "512 B", expected: 512},
+		"bytes word":         {input: "1 byte", expected: 1},
+		"kilobytes":          {input: "10kB", expected: 10000},
+		"upper case":         {input: "10 KB", expected: 10000},
+		"single letter":      {input: "2k", expected: 2000},
+		"kibibytes":          {input: "2KiB", expected: 2048},
+		"short binary":       {input: "2Ki", expected: 2048},
+		"fractional":         {input: "1.5GiB", expected: 1610612736},
+		"fractional SI":      {input: "1.5 GB", expected: 1500000000},
+		"leading point":      {input: ".5 kB", expected: 500},
+		"rounded":            {input: "1.0005 kB", expected: 1001},
+		"rounded down":       {input: "1.0004 kB", expected: 1000},
+		"negative":           {input: "-1.5 MiB", expected: -1572864},
+		"plus sign":          {input: "+3 B", expected: 3},
+		"surrounding spaces": {input: "  7 MB  ", expected: 7000000},
+		"exabytes":           {input: "7EiB", expected: 7 << 60},
+		"largest":            {input: "9223372036854775807", expected: math.MaxInt64},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := ParseBytes(test.input)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestParseBytesErrors(t *testing.T) {
+	var tests = map[string]struct {
+		input        string
+		expectedKind error
+	}{
+		"empty":          {input: "", expectedKind: ErrInvalidNumber},
+		"unit only":      {input: "MB", expectedKind: ErrInvalidNumber},
+		"unknown unit":   {input: "10 XB", expectedKind: ErrInvalidNumber},
+		"two points":     {input: "1.2.3 MB", expectedKind: ErrInvalidNumber},
+		"trailing point": {input: "1. MB", expectedKind: ErrInvalidNumber},
+		"two signs":      {input: "--1 MB", expectedKind: ErrInvalidNumber},
+		"exponent":       {input: "1e3 B", expectedKind: ErrInvalidNumber},
+		"too large":      {input: "8EiB", expectedKind: ErrOverflow},
+		"too many bytes": {input: "9223372036854775808", expectedKind: ErrOverflow},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseBytes(test.input)
+			assert.ErrorIs(t, err, test.expectedKind)
+		})
+	}
+}
+
+func TestParseBytesErrorMessage(t *testing.T) {
+	_, err := ParseBytes("10 XB")
+	assert.EqualError(t, err, `not a valid number: "10 XB" is not a byte size`)
+}
+
+func TestFormatBytesRoundTrip(t *testing.T) {
+	sizes := []int64{0, 1, 999, 1000, 1023, 1024, 1536, 1 << 20, 5 << 30, 1500000000000, 123456789, -98765}
+	for _, units := range []ByteUnits{SI, IEC} {
+		for _, size := range sizes {
+			formatted := FormatBytes(size, units, 3)
+			parsed, err := ParseBytes(formatted)
+			assert.Nil(t, err, formatted)
+			// Three decimal places keep the value within 0.05% of the size
+			assert.InDelta(t, float64(size), float64(parsed), math.Abs(float64(size))*0.0005, formatted)
+			assert.Equal(t, formatted, FormatBytes(parsed, units, 3))
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package numbers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Returned, wrapped with the value, when a number does not fit in the
+// requested type
+var ErrOverflow = errors.New("value out of range")
+
+// Converts an integer to another integer type, returning an error wrapping
+// ErrOverflow instead of silently truncating when the value does not fit.
+//
+//	Convert[int8](int64(100))  // 100, nil
+//	Convert[int8](int64(300))  // 0, value out of range: 300 does not fit in int8
+//	Convert[uint](-1)          // 0, value out of range: -1 does not fit in uint
+func Convert[To, From constraints.Integer](value From) (To, error) {
+	result := To(value)
+	if From(result) != value || (value < 0) != (result < 0) {
+		return 0, fmt.Errorf("%w: %v does not fit in %T", ErrOverflow, value, result)
+	}
+	return result, nil
+}
+
+// Parses a string as an integer of the requested type.  The base is taken
+// from the prefix of the string as with strconv.ParseInt, so "0x1F", "017"
+// and "0b101" are accepted.  Returns an error wrapping ErrInvalidNumber when
+// the string is not an integer or ErrOverflow when it does not fit.
+func ParseInt[T constraints.Integer](value string) (T, error) {
+	if value != "" && value[0] == '-' {
+		parsed, err := strconv.ParseInt(value, 0, 64)
+		if err != nil {
+			return 0, parseError(value, err)
+		}
+		return Convert[T](parsed)
+	}
+	parsed, err := strconv.ParseUint(strings.TrimPrefix(value, "+"), 0, 64)
+	if err != nil {
+		return 0, parseError(value, err)
+	}
+	return Convert[T](parsed)
+}
+
+func parseError(value string, err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return fmt.Errorf("%w: %s", ErrOverflow, value)
+	}
+	return fmt.Errorf("%w: %q", ErrInvalidNumber, value)
+}
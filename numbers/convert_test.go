@@ -0,0 +1,105 @@
+package numbers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvert(t *testing.T) {
+	small, err := Convert[int8](int64(100))
+	assert.Nil(t, err)
+	assert.Equal(t, int8(100), small)
+
+	negative, err := Convert[int16](int64(-300))
+	assert.Nil(t, err)
+	assert.Equal(t, int16(-300), negative)
+
+	unsigned, err := Convert[uint32](int(7))
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(7), unsigned)
+
+	widened, err := Convert[int64](uint32(math.MaxUint32))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(math.MaxUint32), widened)
+}
+
+func TestConvertOverflow(t *testing.T) {
+	var tests = map[string]struct {
+		convert         func() error
+		expectedMessage string
+	}{
+		"too large": {
+			convert:         func() error { _, err := Convert[int8](int64(300)); return err },
+			expectedMessage: "value out of range: 300 does not fit in int8",
+		},
+		"too small": {
+			convert:         func() error { _, err := Convert[int8](-129); return err },
+			expectedMessage: "value out of range: -129 does not fit in int8",
+		},
+		"negative to unsigned": {
+			convert:         func() error { _, err := Convert[uint](-1); return err },
+			expectedMessage: "value out of range: -1 does not fit in uint",
+		},
+		"unsigned to signed of same width": {
+			convert:         func() error { _, err := Convert[int64](uint64(math.MaxUint64)); return err },
+			expectedMessage: "value out of range: 18446744073709551615 does not fit in int64",
+		},
+		"unsigned narrowing": {
+			convert:         func() error { _, err := Convert[uint8](uint16(256)); return err },
+			expectedMessage: "value out of range: 256 does not fit in uint8",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.convert()
+			assert.EqualError(t, err, test.expectedMessage)
+			assert.ErrorIs(t, err, ErrOverflow)
+		})
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	port, err := ParseInt[uint16]("8080")
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(8080), port)
+
+	mask, err := ParseInt[uint8]("0xFF")
+	assert.Nil(t, err)
+	assert.Equal(t, uint8(255), mask)
+
+	offset, err := ParseInt[int32]("-0b101")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(-5), offset)
+
+	explicit, err := ParseInt[int]("+12")
+	assert.Nil(t, err)
+	assert.Equal(t, 12, explicit)
+
+	largest, err := ParseInt[uint64]("18446744073709551615")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), largest)
+}
+
+func TestParseIntErrors(t *testing.T) {
+	var tests = map[string]struct {
+		parse        func() error
+		expectedKind error
+	}{
+		"empty":                {parse: func() error { _, err := ParseInt[int](""); return err }, expectedKind: ErrInvalidNumber},
+		"decimal":              {parse: func() error { _, err := ParseInt[int]("1.5"); return err }, expectedKind: ErrInvalidNumber},
+		"letters":              {parse: func() error { _, err := ParseInt[int]("port"); return err }, expectedKind: ErrInvalidNumber},
+		"does not fit":         {parse: func() error { _, err := ParseInt[uint16]("70000"); return err }, expectedKind: ErrOverflow},
+		"negative to unsigned": {parse: func() error { _, err := ParseInt[uint]("-1"); return err }, expectedKind: ErrOverflow},
+		"beyond 64 bits":       {parse: func() error { _, err := ParseInt[int64]("99999999999999999999"); return err }, expectedKind: ErrOverflow},
+		"beyond 64 bits below": {parse: func() error { _, err := ParseInt[int64]("-99999999999999999999"); return err }, expectedKind: ErrOverflow},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, test.parse(), test.expectedKind)
+		})
+	}
+}
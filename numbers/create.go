@@ -0,0 +1,163 @@
+package numbers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Returned, wrapped with the value, when a string is not a number
+var ErrInvalidNumber = errors.New("not a valid number")
+
+// Converts a string to the smallest suitable number type, similar to Apache
+// Commons NumberUtils.createNumber.  The result is one of:
+//
+//	int        integers that fit in an int
+//	*big.Int   larger integers
+//	int64      integers with an "L" suffix, such as "42L"
+//	float64    decimals and exponents, such as "1.5" or "2e10", or a "D" suffix
+//	float32    numbers with an "F" suffix, such as "1.5F"
+//	*big.Float decimals outside the range of a float64
+//
+// Integers may be written in hexadecimal ("0x1F", "#1F"), octal ("017",
+// "0o17") or binary ("0b101") and all numbers may have a leading sign.
+// Suffixes are case insensitive.
+func CreateNumber(value string) (any, error) {
+	invalid := fmt.Errorf("%w: %q", ErrInvalidNumber, value)
+	if strings.TrimSpace(value) != value || value == "" {
+		return nil, invalid
+	}
+
+	sign, unsigned := "", value
+	if unsigned[0] == '-' || unsigned[0] == '+' {
+		sign, unsigned = unsigned[:1], unsigned[1:]
+	}
+	if unsigned == "" {
+		return nil, invalid
+	}
+
+	if digits, base, ok := radixPrefix(unsigned); ok {
+		if digits == "" || strings.HasPrefix(digits, "_") {
+			return nil, invalid
+		}
+		return createInteger(sign+digits, base, false, invalid)
+	}
+
+	suffix := upper(unsigned[len(unsigned)-1])
+	switch suffix {
+	case 'L':
+		return createInteger(sign+unsigned[:len(unsigned)-1], 10, true, invalid)
+	case 'F', 'D':
+		number := sign + unsigned[:len(unsigned)-1]
+		if !isDecimal(number) {
+			return nil, invalid
+		}
+		bits := 64
+		if suffix == 'F' {
+			bits = 32
+		}
+		parsed, err := strconv.ParseFloat(number, bits)
+		if err != nil {
+			return nil, invalid
+		}
+		if suffix == 'F' {
+			return float32(parsed), nil
+		}
+		return parsed, nil
+	}
+
+	if !isDecimal(value) {
+		return nil, invalid
+	}
+	if IsDigits(unsigned) {
+		return createInteger(value, 10, false, invalid)
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err == nil {
+		return parsed, nil
+	}
+	large, _, err := big.ParseFloat(value, 10, 0, big.ToNearestEven)
+	if err != nil {
+		return nil, invalid
+	}
+	return large, nil
+}
+
+// Returns whether CreateNumber can convert the string to a number
+func IsCreatable(value string) bool {
+	_, err := CreateNumber(value)
+	return err == nil
+}
+
+// Returns the digits following a hexadecimal, octal or binary prefix along
+// with their base
+func radixPrefix(value string) (string, int, bool) {
+	lower := strings.ToLower(value)
+	switch {
+	case strings.HasPrefix(lower, "0x"):
+		return value[2:], 16, true
+	case strings.HasPrefix(lower, "#"):
+		return value[1:], 16, true
+	case strings.HasPrefix(lower, "0o"):
+		return value[2:], 8, true
+	case strings.HasPrefix(lower, "0b"):
+		return value[2:], 2, true
+	case len(value) > 1 && value[0] == '0' && IsDigits(value):
+		return value[1:], 8, true
+	}
+	return "", 0, false
+}
+
+// Parses an integer as an int, or an int64 when long is true, falling back
+// to a *big.Int when it does not fit
+func createInteger(value string, base int, long bool, invalid error) (any, error) {
+	if strings.Contains(value, "_") {
+		return nil, invalid
+	}
+	large, ok := new(big.Int).SetString(value, base)
+	if !ok {
+		return nil, invalid
+	}
+	if !large.IsInt64() {
+		return large, nil
+	}
+	if long {
+		return large.Int64(), nil
+	}
+	if n := large.Int64(); n >= math.MinInt && n <= math.MaxInt {
+		return int(n), nil
+	}
+	return large, nil
+}
+
+// Returns whether the string is a decimal number with an optional sign,
+// decimal point and exponent
+func isDecimal(value string) bool {
+	if value != "" && (value[0] == '-' || value[0] == '+') {
+		value = value[1:]
+	}
+	mantissa, exponent, hasExponent := strings.Cut(strings.ToLower(value), "e")
+	if hasExponent {
+		if exponent != "" && (exponent[0] == '-' || exponent[0] == '+') {
+			exponent = exponent[1:]
+		}
+		if !IsDigits(exponent) {
+			return false
+		}
+	}
+	whole, fraction, hasPoint := strings.Cut(mantissa, ".")
+	if !hasPoint {
+		return IsDigits(whole)
+	}
+	return (whole == "" || IsDigits(whole)) && (fraction == "" || IsDigits(fraction)) && whole+fraction != ""
+}
+
+func upper(b byte) byte {
+	if b >= 'a' && b <= 'z' {
+		return b - 'a' + 'A'
+	}
+	return b
+}
@@ -0,0 +1,99 @@
+package numbers
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateNumber(t *testing.T) {
+	large, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	negativeLarge, _ := new(big.Int).SetString("-18446744073709551616", 10)
+
+	var tests = map[string]struct {
+		input    string
+		expected any
+	}{
+		"integer":               {input: "42", expected: 42},
+		"negative integer":      {input: "-42", expected: -42},
+		"plus sign":             {input: "+42", expected: 42},
+		"zero":                  {input: "0", expected: 0},
+		"long suffix":           {input: "42L", expected: int64(42)},
+		"lower case long":       {input: "-42l", expected: int64(-42)},
+		"large integer":         {input: "123456789012345678901234567890", expected: large},
+		"large hexadecimal":     {input: "-0x10000000000000000", expected: negativeLarge},
+		"hexadecimal":           {input: "0x1F", expected: 31},
+		"upper case hex prefix": {input: "0XFF", expected: 255},
+		"hash hexadecimal":      {input: "#ff", expected: 255},
+		"negative hexadecimal":  {input: "-0x10", expected: -16},
+		"octal":                 {input: "017", expected: 15},
+		"explicit octal":        {input: "0o17", expected: 15},
+		"binary":                {input: "0b101", expected: 5},
+		"decimal":               {input: "1.5", expected: 1.5},
+		"leading point":         {input: ".5", expected: 0.5},
+		"trailing point":        {input: "5.", expected: 5.0},
+		"exponent":              {input: "1e3", expected: 1000.0},
+		"negative exponent":     {input: "-2.5E-2", expected: -0.025},
+		"double suffix":         {input: "2d", expected: 2.0},
+		"float suffix":          {input: "1.5F", expected: float32(1.5)},
+		"float with exponent":   {input: "1e2f", expected: float32(100)},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := CreateNumber(test.input)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestCreateNumberOutOfFloatRange(t *testing.T) {
+	actual, err := CreateNumber("1e400")
+	assert.Nil(t, err)
+	if assert.IsType(t, &big.Float{}, actual) {
+		expected, _, _ := big.ParseFloat("1e400", 10, 0, big.ToNearestEven)
+		assert.Zero(t, expected.Cmp(actual.(*big.Float)))
+	}
+}
+
+func TestCreateNumberInvalid(t *testing.T) {
+	var tests = map[string]string{
+		"empty":                 "",
+		"sign only":             "-",
+		"space":                 " 1",
+		"letters":               "abc",
+		"two points":            "1.2.3",
+		"two signs":             "+-1",
+		"point only":            ".",
+		"exponent only":         "e5",
+		"missing exponent":      "1e",
+		"signed exponent twice": "1e-+5",
+		"hex prefix only":       "0x",
+		"bad hexadecimal":       "0xZZ",
+		"bad octal":             "019",
+		"bad binary":            "0b102",
+		"long decimal":          "1.5L",
+		"suffix only":           "F",
+		"underscores":           "1_000",
+		"float overflow":        "1e50F",
+		"infinity":              "Inf",
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := CreateNumber(input)
+			assert.Nil(t, actual)
+			assert.ErrorIs(t, err, ErrInvalidNumber)
+			assert.False(t, IsCreatable(input))
+		})
+	}
+}
+
+func TestIsCreatable(t *testing.T) {
+	assert.True(t, IsCreatable("0x1F"))
+	assert.True(t, IsCreatable("-1.5e10"))
+	assert.True(t, IsCreatable("10L"))
+	assert.False(t, IsCreatable("ten"))
+}
@@ -0,0 +1,218 @@
+package numbers
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// The integer and floating point types
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+type formatConfig struct {
+	decimals         int
+	groupSeparator   string
+	decimalSeparator string
+}
+
+// Configures FormatNumber and ParseNumber
+type FormatOption func(*formatConfig)
+
+// Formats the number with exactly this many decimal places.  By default
+// integers have none and floating point numbers use as many as needed.
+func WithDecimals(decimals int) FormatOption {
+	return func(c *formatConfig) {
+		c.decimals = decimals
+	}
+}
+
+// Separates groups of thousands with the text, which is "," by default.  An
+// empty separator disables grouping.
+func WithGroupSeparator(separator string) FormatOption {
+	return func(c *formatConfig) {
+		c.groupSeparator = separator
+	}
+}
+
+// Separates the decimal places with the text, which is "." by default
+func WithDecimalSeparator(separator string) FormatOption {
+	return func(c *formatConfig) {
+		c.decimalSeparator = separator
+	}
+}
+
+func newFormatConfig(opts []FormatOption) formatConfig {
+	config := formatConfig{decimals: -1, groupSeparator: ",", decimalSeparator: "."}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+// Formats the number with its thousands grouped, such as "1,234,567.89".
+// Integers are formatted exactly, even when they are too large to be
+// represented by a float64.
+//
+//	FormatNumber(1234567)                   // "1,234,567"
+//	FormatNumber(-1234.5, WithDecimals(2))  // "-1,234.50"
+//	FormatNumber(1234.5, WithGroupSeparator("."), WithDecimalSeparator(","))
+//	                                        // "1.234,5"
+func FormatNumber[T Number](value T, opts ...FormatOption) string {
+	config := newFormatConfig(opts)
+
+	var text string
+	switch rv := reflect.ValueOf(value); rv.Kind() {
+	case reflect.Float32:
+		text = strconv.FormatFloat(rv.Float(), 'f', config.decimals, 32)
+	case reflect.Float64:
+		text = strconv.FormatFloat(rv.Float(), 'f', config.decimals, 64)
+	default:
+		text = integerText(rv)
+		if config.decimals > 0 {
+			text += "." + strings.Repeat("0", config.decimals)
+		}
+	}
+
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign, text = "-", text[1:]
+	}
+	whole, fraction, hasFraction := strings.Cut(text, ".")
+	if !IsDigits(whole) {
+		// NaN and infinities
+		return sign + text
+	}
+	result := sign + group(whole, config.groupSeparator)
+	if hasFraction {
+		result += config.decimalSeparator + fraction
+	}
+	return result
+}
+
+// Returns the decimal digits of an integer value
+func integerText(rv reflect.Value) string {
+	switch rv.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10)
+	}
+	return strconv.FormatInt(rv.Int(), 10)
+}
+
+// Inserts the separator between each group of three digits
+func group(digits string, separator string) string {
+	if separator == "" || len(digits) <= 3 {
+		return digits
+	}
+	var builder strings.Builder
+	first := len(digits) % 3
+	if first == 0 {
+		first = 3
+	}
+	builder.WriteString(digits[:first])
+	for i := first; i < len(digits); i += 3 {
+		builder.WriteString(separator)
+		builder.WriteString(digits[i : i+3])
+	}
+	return builder.String()
+}
+
+// Parses a number written by FormatNumber with the same separators.  The
+// whole number may also be written without grouping, but when it is grouped
+// every group after the first must have three digits.  The decimals option
+// is ignored.  Returns an error wrapping ErrInvalidNumber when the text is
+// not a number.
+func ParseNumber(value string, opts ...FormatOption) (float64, error) {
+	config := newFormatConfig(opts)
+	invalid := fmt.Errorf("%w: %q", ErrInvalidNumber, value)
+
+	text := strings.TrimSpace(value)
+	whole, fraction, hasFraction := strings.Cut(text, config.decimalSeparator)
+	if config.groupSeparator != "" && strings.Contains(whole, config.groupSeparator) {
+		digits := whole
+		if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+			digits = digits[1:]
+		}
+		if !grouped(digits, config.groupSeparator) {
+			return 0, invalid
+		}
+		whole = strings.ReplaceAll(whole, config.groupSeparator, "")
+	}
+	if hasFraction {
+		whole += "." + fraction
+	}
+	if !IsParsable(strings.TrimPrefix(whole, "+")) {
+		return 0, invalid
+	}
+	result, err := strconv.ParseFloat(whole, 64)
+	if err != nil {
+		return 0, invalid
+	}
+	return result, nil
+}
+
+// Returns whether the digits are separated into groups of three, of which
+// only the first may be shorter
+func grouped(digits string, separator string) bool {
+	groups := strings.Split(digits, separator)
+	if len(groups[0]) == 0 || len(groups[0]) > 3 {
+		return false
+	}
+	for _, g := range groups[1:] {
+		if len(g) != 3 {
+			return false
+		}
+	}
+	return true
+}
+
+// Formats the number in compact notation with at most precision decimal
+// places, using the suffixes K, M, B and T for thousands, millions, billions
+// and trillions.  Values below 1000 have no suffix.
+//
+//	FormatCompact(1234, 1)       // "1.2K"
+//	FormatCompact(-5600000, 1)   // "-5.6M"
+//	FormatCompact(999999, 1)     // "1M"
+//	FormatCompact(42, 1)         // "42"
+func FormatCompact[T Number](value T, precision int) string {
+	magnitude := float64(value)
+	if math.IsNaN(magnitude) || math.IsInf(magnitude, 0) {
+		return strconv.FormatFloat(magnitude, 'f', -1, 64)
+	}
+	sign := ""
+	if magnitude < 0 {
+		sign, magnitude = "-", -magnitude
+	}
+	text, unit := scale(magnitude, 1000, len(compactSuffixes)-1, precision)
+	return sign + text + compactSuffixes[unit]
+}
+
+var compactSuffixes = []string{"", "K", "M", "B", "T"}
+
+// Returns the number followed by its English ordinal suffix, such as "1st",
+// "2nd", "3rd", "4th", "11th", "12th", "13th" or "101st"
+func Ordinal[T constraints.Integer](value T) string {
+	text := integerText(reflect.ValueOf(value))
+	digits := strings.TrimPrefix(text, "-")
+	tens := digits
+	if len(tens) > 2 {
+		tens = tens[len(tens)-2:]
+	}
+	if tens == "11" || tens == "12" || tens == "13" {
+		return text + "th"
+	}
+	switch digits[len(digits)-1] {
+	case '1':
+		return text + "st"
+	case '2':
+		return text + "nd"
+	case '3':
+		return text + "rd"
+	}
+	return text + "th"
+}
@@ -0,0 +1,145 @@
+package numbers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type celsius float64
+
+func TestFormatNumber(t *testing.T) {
+	var tests = map[string]struct {
+		actual   string
+		expected string
+	}{
+		"small":                  {actual: FormatNumber(123), expected: "123"},
+		"thousands":              {actual: FormatNumber(1234), expected: "1,234"},
+		"millions":               {actual: FormatNumber(1234567), expected: "1,234,567"},
+		"group boundary":         {actual: FormatNumber(123456), expected: "123,456"},
+		"negative":               {actual: FormatNumber(-1234567), expected: "-1,234,567"},
+		"largest unsigned":       {actual: FormatNumber(uint64(math.MaxUint64)), expected: "18,446,744,073,709,551,615"},
+		"smallest int64":         {actual: FormatNumber(int64(math.MinInt64)), expected: "-9,223,372,036,854,775,808"},
+		"float":                  {actual: FormatNumber(1234.5), expected: "1,234.5"},
+		"float decimals":         {actual: FormatNumber(-1234.5, WithDecimals(2)), expected: "-1,234.50"},
+		"rounded":                {actual: FormatNumber(1234.5678, WithDecimals(2)), expected: "1,234.57"},
+		"integer decimals":       {actual: FormatNumber(1000, WithDecimals(2)), expected: "1,000.00"},
+		"float32":                {actual: FormatNumber(float32(1234.1)), expected: "1,234.1"},
+		"named type":             {actual: FormatNumber(celsius(-1500.25)), expected: "-1,500.25"},
+		"european":               {actual: FormatNumber(1234567.5, WithGroupSeparator("."), WithDecimalSeparator(",")), expected: "1.234.567,5"},
+		"spaces":                 {actual: FormatNumber(1234567, WithGroupSeparator(" ")), expected: "1 234 567"},
+		"no grouping":            {actual: FormatNumber(1234567.25, WithGroupSeparator("")), expected: "1234567.25"},
+		"multi-byte separator":   {actual: FormatNumber(1234567, WithGroupSeparator("\u2009")), expected: "1\u2009234\u2009567"},
+		"not a number":           {actual: FormatNumber(math.NaN()), expected: "NaN"},
+		"negative infinity":      {actual: FormatNumber(math.Inf(-1)), expected: "-Inf"},
+		"zero":                   {actual: FormatNumber(0.0), expected: "0"},
+		"fraction below one":     {actual: FormatNumber(0.125), expected: "0.125"},
+		"zero decimals of float": {actual: FormatNumber(2.5, WithDecimals(0)), expected: "2"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.actual)
+		})
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	actual, err := ParseNumber("1,234,567.5")
+	assert.Nil(t, err)
+	assert.Equal(t, 1234567.5, actual)
+
+	actual, err = ParseNumber("-1.234,5", WithGroupSeparator("."), WithDecimalSeparator(","))
+	assert.Nil(t, err)
+	assert.Equal(t, -1234.5, actual)
+
+	_, err = ParseNumber("12a")
+	assert.ErrorIs(t, err, ErrInvalidNumber)
+	_, err = ParseNumber("1.2.3")
+	assert.ErrorIs(t, err, ErrInvalidNumber)
+	_, err = ParseNumber("1,234.5", WithGroupSeparator("."), WithDecimalSeparator(","))
+	assert.ErrorIs(t, err, ErrInvalidNumber)
+
+	actual, err = ParseNumber("+1234567")
+	assert.Nil(t, err)
+	assert.Equal(t, 1234567.0, actual)
+
+	for _, malformed := range []string{"1,2,3", "1,,234", ",123", "1234,567", "1,234,", "-,123", "1,234,56.5"} {
+		_, err = ParseNumber(malformed)
+		assert.ErrorIs(t, err, ErrInvalidNumber, malformed)
+	}
+}
+
+func TestFormatNumberRoundTrip(t *testing.T) {
+	values := []float64{0, 1, -1, 999.5, 1000, 1234567.891, -98765432.1, 0.001}
+	options := [][]FormatOption{
+		nil,
+		{WithDecimals(3)},
+		{WithGroupSeparator("."), WithDecimalSeparator(",")},
+		{WithGroupSeparator("'"), WithDecimalSeparator(".")},
+		{WithGroupSeparator("")},
+	}
+	for _, opts := range options {
+		for _, value := range values {
+			formatted := FormatNumber(value, opts...)
+			parsed, err := ParseNumber(formatted, opts...)
+			assert.Nil(t, err, formatted)
+			assert.Equal(t, value, parsed, formatted)
+		}
+	}
+}
+
+func TestFormatCompact(t *testing.T) {
+	var tests = map[string]struct {
+		actual   string
+		expected string
+	}{
+		"small":             {actual: FormatCompact(42, 1), expected: "42"},
+		"small decimal":     {actual: FormatCompact(4.25, 1), expected: "4.2"},
+		"thousands":         {actual: FormatCompact(1234, 1), expected: "1.2K"},
+		"exact thousands":   {actual: FormatCompact(2000, 1), expected: "2K"},
+		"millions":          {actual: FormatCompact(-5600000, 1), expected: "-5.6M"},
+		"rounds up":         {actual: FormatCompact(999999, 1), expected: "1M"},
+		"rounds below 1000": {actual: FormatCompact(999.96, 1), expected: "1K"},
+		"billions":          {actual: FormatCompact(uint64(7250000000), 2), expected: "7.25B"},
+		"trillions":         {actual: FormatCompact(int64(3e12), 1), expected: "3T"},
+		"beyond trillions":  {actual: FormatCompact(4.5e15, 1), expected: "4500T"},
+		"infinity":          {actual: FormatCompact(math.Inf(-1), 1), expected: "-Inf"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.actual)
+		})
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	var tests = map[string]struct {
+		actual   string
+		expected string
+	}{
+		"first":            {actual: Ordinal(1), expected: "1st"},
+		"second":           {actual: Ordinal(2), expected: "2nd"},
+		"third":            {actual: Ordinal(3), expected: "3rd"},
+		"fourth":           {actual: Ordinal(4), expected: "4th"},
+		"zeroth":           {actual: Ordinal(0), expected: "0th"},
+		"eleventh":         {actual: Ordinal(11), expected: "11th"},
+		"twelfth":          {actual: Ordinal(12), expected: "12th"},
+		"thirteenth":       {actual: Ordinal(13), expected: "13th"},
+		"twenty first":     {actual: Ordinal(21), expected: "21st"},
+		"twenty second":    {actual: Ordinal(22), expected: "22nd"},
+		"hundred first":    {actual: Ordinal(101), expected: "101st"},
+		"hundred eleventh": {actual: Ordinal(111), expected: "111th"},
+		"negative":         {actual: Ordinal(-3), expected: "-3rd"},
+		"negative teen":    {actual: Ordinal(int8(-112)), expected: "-112th"},
+		"unsigned":         {actual: Ordinal(uint64(1002)), expected: "1002nd"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.actual)
+		})
+	}
+}
@@ -0,0 +1,417 @@
+package numbers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Returned when a fraction would have a zero denominator
+var ErrDivideByZero = errors.New("division by zero")
+
+// An exact rational number, always held in its reduced form with a positive
+// denominator.  The numerator and denominator are int64 values while they
+// fit; when a result overflows the fraction falls back to a big.Rat so that
+// arithmetic stays exact.  IsBig reports which form is used.
+//
+// The zero value is the fraction 0.  Fractions are immutable values that are
+// safe to copy and to share between goroutines.
+type Fraction struct {
+	num int64
+	den int64
+	big *big.Rat
+}
+
+// Creates the fraction numerator/denominator in its reduced form.  Returns an
+// error wrapping ErrDivideByZero when the denominator is zero.
+func NewFraction(numerator, denominator int64) (Fraction, error) {
+	if denominator == 0 {
+		return Fraction{}, fmt.Errorf("%w: %d/0", ErrDivideByZero, numerator)
+	}
+	return reduce(numerator, denominator), nil
+}
+
+// Creates a fraction from a whole number
+func FractionOf(whole int64) Fraction {
+	return Fraction{num: whole, den: 1}
+}
+
+// Creates a fraction equal to the big.Rat, which is copied
+func FractionFromRat(value *big.Rat) Fraction {
+	return fromRat(new(big.Rat).Set(value))
+}
+
+// Creates a fraction with the exact value of the float64.  Note that most
+// decimals, such as 0.1, cannot be represented exactly by a float64; use
+// ParseFraction to convert the decimal text instead.  Returns an error
+// wrapping ErrInvalidNumber for NaN and infinities.
+func FractionFromFloat64(value float64) (Fraction, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Fraction{}, fmt.Errorf("%w: %v", ErrInvalidNumber, value)
+	}
+	return fromRat(new(big.Rat).SetFloat64(value)), nil
+}
+
+// Parses a fraction from one of the forms:
+//
+//	"3/4", "-7/2"   a numerator and denominator
+//	"3 1/2"         a whole number and a proper fraction, as written by ProperString
+//	"0.75", "-2"    a decimal or integer, converted exactly
+//	"1.5e-3"        a decimal with an exponent
+//
+// Returns an error wrapping ErrInvalidNumber when the text is not a fraction
+// or ErrDivideByZero when the denominator is zero.
+func ParseFraction(value string) (Fraction, error) {
+	invalid := fmt.Errorf("%w: %q", ErrInvalidNumber, value)
+	parts := strings.Fields(value)
+	switch len(parts) {
+	case 1:
+		return parseRat(parts[0], invalid)
+	case 2:
+		whole, fraction := parts[0], parts[1]
+		negative := strings.HasPrefix(whole, "-")
+		if !IsDigits(strings.TrimPrefix(whole, "-")) || !strings.Contains(fraction, "/") || !IsDigits(strings.ReplaceAll(fraction, "/", "")) {
+			return Fraction{}, invalid
+		}
+		wholePart, err := parseRat(strings.TrimPrefix(whole, "-"), invalid)
+		if err != nil {
+			return Fraction{}, err
+		}
+		fractionPart, err := parseRat(fraction, invalid)
+		if err != nil {
+			return Fraction{}, err
+		}
+		result := wholePart.Add(fractionPart)
+		if negative {
+			result = result.Negate()
+		}
+		return result, nil
+	}
+	return Fraction{}, invalid
+}
+
+func parseRat(value string, invalid error) (Fraction, error) {
+	numerator, denominator, isRatio := strings.Cut(value, "/")
+	if isRatio {
+		if !IsDigits(strings.TrimPrefix(numerator, "-")) || !IsDigits(denominator) {
+			return Fraction{}, invalid
+		}
+		if strings.Trim(denominator, "0") == "" {
+			return Fraction{}, fmt.Errorf("%w: %s", ErrDivideByZero, value)
+		}
+	} else if !isDecimal(value) {
+		return Fraction{}, invalid
+	}
+	rat, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return Fraction{}, invalid
+	}
+	return fromRat(rat), nil
+}
+
+// Returns the reduced fraction, falling back to a big.Rat when the sign of
+// math.MinInt64 cannot be moved to the numerator
+func reduce(numerator, denominator int64) Fraction {
+	if numerator == math.MinInt64 || denominator == math.MinInt64 {
+		return fromRat(big.NewRat(numerator, denominator))
+	}
+	if denominator < 0 {
+		numerator, denominator = -numerator, -denominator
+	}
+	if divisor := gcd(abs(numerator), denominator); divisor > 1 {
+		numerator, denominator = numerator/divisor, denominator/divisor
+	}
+	return Fraction{num: numerator, den: denominator}
+}
+
+// Returns the fraction for the rat, using int64 values when they fit.  The
+// rat must not be modified afterwards.
+func fromRat(value *big.Rat) Fraction {
+	if value.Num().IsInt64() && value.Denom().IsInt64() {
+		return Fraction{num: value.Num().Int64(), den: value.Denom().Int64()}
+	}
+	return Fraction{big: value}
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func abs(value int64) int64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+func addExact(a, b int64) (int64, bool) {
+	result := a + b
+	return result, (a >= 0) != (b >= 0) || (result >= 0) == (a >= 0)
+}
+
+func mulExact(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	result := a * b
+	if result/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+	return result, true
+}
+
+// Returns the numerator and denominator of a fraction that is not big,
+// treating the zero value as 0/1
+func (f Fraction) parts() (int64, int64) {
+	if f.den == 0 {
+		return 0, 1
+	}
+	return f.num, f.den
+}
+
+// Returns the value of the fraction as a new big.Rat
+func (f Fraction) Rat() *big.Rat {
+	if f.big != nil {
+		return new(big.Rat).Set(f.big)
+	}
+	return big.NewRat(f.parts())
+}
+
+// Returns whether the numerator or denominator is too large for an int64
+func (f Fraction) IsBig() bool {
+	return f.big != nil
+}
+
+// Returns the reduced numerator and denominator, or an error wrapping
+// ErrOverflow when they do not fit in an int64
+func (f Fraction) Int64() (int64, int64, error) {
+	if f.big != nil {
+		return 0, 0, fmt.Errorf("%w: %s does not fit in int64", ErrOverflow, f.big.RatString())
+	}
+	numerator, denominator := f.parts()
+	return numerator, denominator, nil
+}
+
+// Returns the nearest float64 to the fraction
+func (f Fraction) Float64() float64 {
+	numerator, denominator := f.parts()
+	// Both values convert exactly, so the division is correctly rounded
+	if f.big == nil && abs(numerator) <= 1<<53 && denominator <= 1<<53 {
+		return float64(numerator) / float64(denominator)
+	}
+	result, _ := f.Rat().Float64()
+	return result
+}
+
+// Returns -1, 0 or 1 when the fraction is negative, zero or positive
+func (f Fraction) Sign() int {
+	if f.big != nil {
+		return f.big.Sign()
+	}
+	switch {
+	case f.num < 0:
+		return -1
+	case f.num > 0:
+		return 1
+	}
+	return 0
+}
+
+// Returns whether the fraction is a whole number
+func (f Fraction) IsInteger() bool {
+	if f.big != nil {
+		return f.big.IsInt()
+	}
+	_, denominator := f.parts()
+	return denominator == 1
+}
+
+// Returns f + other
+func (f Fraction) Add(other Fraction) Fraction {
+	if f.big == nil && other.big == nil {
+		a, b := f.parts()
+		c, d := other.parts()
+		if b == d {
+			if numerator, ok := addExact(a, c); ok {
+				return reduce(numerator, b)
+			}
+		} else {
+			ad, ok1 := mulExact(a, d)
+			cb, ok2 := mulExact(c, b)
+			bd, ok3 := mulExact(b, d)
+			numerator, ok4 := addExact(ad, cb)
+			if ok1 && ok2 && ok3 && ok4 {
+				return reduce(numerator, bd)
+			}
+		}
+	}
+	return fromRat(new(big.Rat).Add(f.Rat(), other.Rat()))
+}
+
+// Returns f - other
+func (f Fraction) Sub(other Fraction) Fraction {
+	return f.Add(other.Negate())
+}
+
+// Returns f * other
+func (f Fraction) Mul(other Fraction) Fraction {
+	if f.big == nil && other.big == nil {
+		a, b := f.parts()
+		c, d := other.parts()
+		// Cross reduce first so that the products are as small as possible
+		if g := gcd(abs(a), d); g > 1 {
+			a, d = a/g, d/g
+		}
+		if g := gcd(abs(c), b); g > 1 {
+			c, b = c/g, b/g
+		}
+		numerator, ok1 := mulExact(a, c)
+		denominator, ok2 := mulExact(b, d)
+		if ok1 && ok2 {
+			return reduce(numerator, denominator)
+		}
+	}
+	return fromRat(new(big.Rat).Mul(f.Rat(), other.Rat()))
+}
+
+// Returns f / other, or an error wrapping ErrDivideByZero when other is zero
+func (f Fraction) Div(other Fraction) (Fraction, error) {
+	inverse, err := other.Invert()
+	if err != nil {
+		return Fraction{}, err
+	}
+	return f.Mul(inverse), nil
+}
+
+// Returns f raised to the power, which may be negative.  Returns an error
+// wrapping ErrDivideByZero when zero is raised to a negative power.
+func (f Fraction) Pow(power int) (Fraction, error) {
+	base := f
+	if power < 0 {
+		inverse, err := f.Invert()
+		if err != nil {
+			return Fraction{}, err
+		}
+		base = inverse
+	}
+	result := FractionOf(1)
+	for remaining := power; remaining != 0; remaining /= 2 {
+		if remaining%2 != 0 {
+			result = result.Mul(base)
+		}
+		base = base.Mul(base)
+	}
+	return result, nil
+}
+
+// Returns -f
+func (f Fraction) Negate() Fraction {
+	if f.big == nil && f.num != math.MinInt64 {
+		numerator, denominator := f.parts()
+		return Fraction{num: -numerator, den: denominator}
+	}
+	return fromRat(new(big.Rat).Neg(f.Rat()))
+}
+
+// Returns the absolute value of f
+func (f Fraction) Abs() Fraction {
+	if f.Sign() < 0 {
+		return f.Negate()
+	}
+	return f
+}
+
+// Returns 1/f, or an error wrapping ErrDivideByZero when f is zero
+func (f Fraction) Invert() (Fraction, error) {
+	if f.Sign() == 0 {
+		return Fraction{}, fmt.Errorf("%w: cannot invert 0", ErrDivideByZero)
+	}
+	if f.big != nil {
+		return fromRat(new(big.Rat).Inv(f.big)), nil
+	}
+	numerator, denominator := f.parts()
+	return reduce(denominator, numerator), nil
+}
+
+// Returns -1, 0 or 1 when f is less than, equal to or greater than other
+func (f Fraction) Compare(other Fraction) int {
+	if f.big == nil && other.big == nil {
+		a, b := f.parts()
+		c, d := other.parts()
+		left, ok1 := mulExact(a, d)
+		right, ok2 := mulExact(c, b)
+		if ok1 && ok2 {
+			switch {
+			case left < right:
+				return -1
+			case left > right:
+				return 1
+			}
+			return 0
+		}
+	}
+	return f.Rat().Cmp(other.Rat())
+}
+
+// Returns whether the fractions represent the same number
+func (f Fraction) Equal(other Fraction) bool {
+	return f.Compare(other) == 0
+}
+
+// Returns the fraction as "numerator/denominator", or only the numerator for
+// whole numbers, such as "7/2", "-1/3" or "4"
+func (f Fraction) String() string {
+	if f.big != nil {
+		return f.big.RatString()
+	}
+	numerator, denominator := f.parts()
+	if denominator == 1 {
+		return fmt.Sprint(numerator)
+	}
+	return fmt.Sprintf("%d/%d", numerator, denominator)
+}
+
+// Returns the fraction as a whole number followed by a proper fraction, such
+// as "3 1/2", "-1 1/3", "1/2" or "4".  ParseFraction reads this form.
+func (f Fraction) ProperString() string {
+	rat := f.Rat()
+	whole, remainder := new(big.Int).QuoRem(rat.Num(), rat.Denom(), new(big.Int))
+	switch {
+	case remainder.Sign() == 0:
+		return whole.String()
+	case whole.Sign() == 0:
+		return rat.RatString()
+	}
+	return fmt.Sprintf("%s %s/%s", whole, remainder.Abs(remainder), rat.Denom())
+}
+
+// Marshals the fraction as a JSON string in the form written by String
+func (f Fraction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.String())
+}
+
+// Unmarshals a JSON string in any form accepted by ParseFraction, or a JSON
+// number which is converted exactly from its decimal text
+func (f *Fraction) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if strings.HasPrefix(text, `"`) {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseFraction(text)
+	if err != nil {
+		return err
+	}
+	*f = parsed
+	return nil
+}
@@ -0,0 +1,314 @@
+package numbers
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fraction(t *testing.T, value string) Fraction {
+	t.Helper()
+	result, err := ParseFraction(value)
+	assert.Nil(t, err)
+	return result
+}
+
+func TestNewFraction(t *testing.T) {
+	var tests = map[string]struct {
+		numerator   int64
+		denominator int64
+		expected    string
+	}{
+		"reduced":                {numerator: 6, denominator: 8, expected: "3/4"},
+		"whole":                  {numerator: 10, denominator: 5, expected: "2"},
+		"zero":                   {numerator: 0, denominator: -7, expected: "0"},
+		"negative denominator":   {numerator: 1, denominator: -3, expected: "-1/3"},
+		"both negative":          {numerator: -4, denominator: -6, expected: "2/3"},
+		"minimum numerator":      {numerator: math.MinInt64, denominator: 2, expected: "-4611686018427387904"},
+		"minimum denominator":    {numerator: 1, denominator: math.MinInt64, expected: "-1/9223372036854775808"},
+		"minimum over minus one": {numerator: math.MinInt64, denominator: -1, expected: "9223372036854775808"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := NewFraction(test.numerator, test.denominator)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual.String())
+		})
+	}
+}
+
+func TestNewFractionZeroDenominator(t *testing.T) {
+	_, err := NewFraction(1, 0)
+	assert.EqualError(t, err, "division by zero: 1/0")
+	assert.ErrorIs(t, err, ErrDivideByZero)
+}
+
+func TestFractionZeroValue(t *testing.T) {
+	var zero Fraction
+	assert.Equal(t, "0", zero.String())
+	assert.Equal(t, 0, zero.Sign())
+	assert.True(t, zero.Equal(FractionOf(0)))
+	assert.Equal(t, "1/2", zero.Add(fraction(t, "1/2")).String())
+}
+
+func TestFractionFromFloat64(t *testing.T) {
+	half, err := FractionFromFloat64(0.5)
+	assert.Nil(t, err)
+	assert.Equal(t, "1/2", half.String())
+
+	tenth, err := FractionFromFloat64(0.1)
+	assert.Nil(t, err)
+	assert.Equal(t, "3602879701896397/36028797018963968", tenth.String())
+
+	_, err = FractionFromFloat64(math.NaN())
+	assert.ErrorIs(t, err, ErrInvalidNumber)
+	_, err = FractionFromFloat64(math.Inf(-1))
+	assert.ErrorIs(t, err, ErrInvalidNumber)
+}
+
+func TestFractionFromRat(t *testing.T) {
+	rat := big.NewRat(3, 9)
+	result := FractionFromRat(rat)
+	rat.SetInt64(5)
+	assert.Equal(t, "1/3", result.String())
+	assert.False(t, result.IsBig())
+}
+
+func TestParseFraction(t *testing.T) {
+	var tests = map[string]struct {
+		input    string
+		expected string
+	}{
+		"fraction":               {input: "3/4", expected: "3/4"},
+		"reduced":                {input: "2/4", expected: "1/2"},
+		"negative":               {input: "-7/2", expected: "-7/2"},
+		"mixed":                  {input: "3 1/2", expected: "7/2"},
+		"negative mixed":         {input: "-3 1/2", expected: "-7/2"},
+		"mixed with extra space": {input: "  1   2/3 ", expected: "5/3"},
+		"decimal":                {input: "0.75", expected: "3/4"},
+		"negative decimal":       {input: "-1.125", expected: "-9/8"},
+		"exact tenth":            {input: "0.1", expected: "1/10"},
+		"integer":                {input: "42", expected: "42"},
+		"exponent":               {input: "1.5e-3", expected: "3/2000"},
+		"large":                  {input: "1/123456789012345678901234567890", expected: "1/123456789012345678901234567890"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, fraction(t, test.input).String())
+		})
+	}
+}
+
+func TestParseFractionErrors(t *testing.T) {
+	var tests = map[string]struct {
+		input        string
+		expectedKind error
+	}{
+		"empty":                    {input: "", expectedKind: ErrInvalidNumber},
+		"letters":                  {input: "half", expectedKind: ErrInvalidNumber},
+		"missing denominator":      {input: "1/", expectedKind: ErrInvalidNumber},
+		"two slashes":              {input: "1/2/3", expectedKind: ErrInvalidNumber},
+		"negative denominator":     {input: "1/-2", expectedKind: ErrInvalidNumber},
+		"decimal numerator":        {input: "1.5/2", expectedKind: ErrInvalidNumber},
+		"mixed without fraction":   {input: "3 1", expectedKind: ErrInvalidNumber},
+		"mixed with decimal whole": {input: "3.5 1/2", expectedKind: ErrInvalidNumber},
+		"mixed with negative part": {input: "3 -1/2", expectedKind: ErrInvalidNumber},
+		"three parts":              {input: "1 1/2 1/2", expectedKind: ErrInvalidNumber},
+		"hexadecimal":              {input: "0x10", expectedKind: ErrInvalidNumber},
+		"zero denominator":         {input: "1/0", expectedKind: ErrDivideByZero},
+		"mixed zero denominator":   {input: "1 1/00", expectedKind: ErrDivideByZero},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseFraction(test.input)
+			assert.ErrorIs(t, err, test.expectedKind)
+		})
+	}
+}
+
+func TestFractionArithmetic(t *testing.T) {
+	var tests = map[string]struct {
+		actual   func() Fraction
+		expected string
+	}{
+		"add":                  {actual: func() Fraction { return fraction(t, "1/2").Add(fraction(t, "1/3")) }, expected: "5/6"},
+		"add same denominator": {actual: func() Fraction { return fraction(t, "1/4").Add(fraction(t, "1/4")) }, expected: "1/2"},
+		"add to whole":         {actual: func() Fraction { return fraction(t, "1/3").Add(fraction(t, "2/3")) }, expected: "1"},
+		"sub":                  {actual: func() Fraction { return fraction(t, "1/2").Sub(fraction(t, "3/4")) }, expected: "-1/4"},
+		"mul":                  {actual: func() Fraction { return fraction(t, "2/3").Mul(fraction(t, "9/4")) }, expected: "3/2"},
+		"mul by zero":          {actual: func() Fraction { return fraction(t, "2/3").Mul(Fraction{}) }, expected: "0"},
+		"negate":               {actual: func() Fraction { return fraction(t, "2/3").Negate() }, expected: "-2/3"},
+		"abs":                  {actual: func() Fraction { return fraction(t, "-2/3").Abs() }, expected: "2/3"},
+		"abs of positive":      {actual: func() Fraction { return fraction(t, "2/3").Abs() }, expected: "2/3"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.actual().String())
+		})
+	}
+}
+
+func TestFractionDivAndInvert(t *testing.T) {
+	quotient, err := fraction(t, "3/4").Div(fraction(t, "-3/8"))
+	assert.Nil(t, err)
+	assert.Equal(t, "-2", quotient.String())
+
+	inverse, err := fraction(t, "-2/5").Invert()
+	assert.Nil(t, err)
+	assert.Equal(t, "-5/2", inverse.String())
+
+	_, err = fraction(t, "3/4").Div(Fraction{})
+	assert.ErrorIs(t, err, ErrDivideByZero)
+	_, err = FractionOf(0).Invert()
+	assert.EqualError(t, err, "division by zero: cannot invert 0")
+}
+
+func TestFractionPow(t *testing.T) {
+	var tests = map[string]struct {
+		value    string
+		power    int
+		expected string
+	}{
+		"square":           {value: "2/3", power: 2, expected: "4/9"},
+		"cube of negative": {value: "-1/2", power: 3, expected: "-1/8"},
+		"zero power":       {value: "5/7", power: 0, expected: "1"},
+		"zero to zero":     {value: "0", power: 0, expected: "1"},
+		"negative power":   {value: "2/3", power: -2, expected: "9/4"},
+		"beyond int64":     {value: "3/2", power: 41, expected: "36472996377170786403/2199023255552"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := fraction(t, test.value).Pow(test.power)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual.String())
+		})
+	}
+
+	_, err := FractionOf(0).Pow(-1)
+	assert.ErrorIs(t, err, ErrDivideByZero)
+}
+
+func TestFractionOverflowFallsBackToRat(t *testing.T) {
+	largest := FractionOf(math.MaxInt64)
+
+	sum := largest.Add(FractionOf(1))
+	assert.True(t, sum.IsBig())
+	assert.Equal(t, "9223372036854775808", sum.String())
+	_, _, err := sum.Int64()
+	assert.EqualError(t, err, "value out of range: 9223372036854775808 does not fit in int64")
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	back := sum.Sub(FractionOf(1))
+	assert.False(t, back.IsBig())
+	numerator, denominator, err := back.Int64()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(math.MaxInt64), numerator)
+	assert.Equal(t, int64(1), denominator)
+
+	tiny, _ := NewFraction(1, math.MaxInt64)
+	product := tiny.Mul(tiny)
+	assert.True(t, product.IsBig())
+	assert.Equal(t, "1/85070591730234615847396907784232501249", product.String())
+	assert.Equal(t, "1", product.Mul(largest).Mul(largest).String())
+
+	assert.Equal(t, "-9223372036854775808", FractionOf(math.MinInt64).String())
+	assert.True(t, FractionOf(math.MinInt64).Negate().IsBig())
+	assert.True(t, FractionOf(math.MinInt64).Abs().Equal(sum))
+}
+
+func TestFractionCrossReducedMulStaysSmall(t *testing.T) {
+	a, _ := NewFraction(math.MaxInt64, 3)
+	b, _ := NewFraction(3, math.MaxInt64)
+	product := a.Mul(b)
+	assert.False(t, product.IsBig())
+	assert.Equal(t, "1", product.String())
+}
+
+func TestFractionCompare(t *testing.T) {
+	var tests = map[string]struct {
+		left     Fraction
+		right    Fraction
+		expected int
+	}{
+		"less":          {left: fraction(t, "1/3"), right: fraction(t, "1/2"), expected: -1},
+		"equal":         {left: fraction(t, "2/4"), right: fraction(t, "0.5"), expected: 0},
+		"greater":       {left: fraction(t, "-1/3"), right: fraction(t, "-1/2"), expected: 1},
+		"zero value":    {left: Fraction{}, right: fraction(t, "0"), expected: 0},
+		"big and small": {left: FractionOf(math.MaxInt64).Add(FractionOf(1)), right: FractionOf(math.MaxInt64), expected: 1},
+		"products overflow": {
+			left:     fraction(t, "9223372036854775806/9223372036854775807"),
+			right:    fraction(t, "9223372036854775805/9223372036854775806"),
+			expected: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.left.Compare(test.right))
+			assert.Equal(t, -test.expected, test.right.Compare(test.left))
+			assert.Equal(t, test.expected == 0, test.left.Equal(test.right))
+		})
+	}
+}
+
+func TestFractionConversions(t *testing.T) {
+	assert.Equal(t, 0.75, fraction(t, "3/4").Float64())
+	assert.Equal(t, 1e30, fraction(t, "1000000000000000000000000000000").Float64())
+	assert.Equal(t, 0.0, Fraction{}.Float64())
+	assert.Equal(t, big.NewRat(-7, 2), fraction(t, "-3 1/2").Rat())
+	assert.True(t, fraction(t, "4/2").IsInteger())
+	assert.False(t, fraction(t, "3/2").IsInteger())
+	assert.Equal(t, -1, fraction(t, "-0.1").Sign())
+}
+
+func TestFractionProperString(t *testing.T) {
+	var tests = map[string]struct {
+		input    string
+		expected string
+	}{
+		"improper":          {input: "7/2", expected: "3 1/2"},
+		"negative improper": {input: "-7/2", expected: "-3 1/2"},
+		"proper":            {input: "1/2", expected: "1/2"},
+		"negative proper":   {input: "-1/2", expected: "-1/2"},
+		"whole":             {input: "4", expected: "4"},
+		"zero":              {input: "0", expected: "0"},
+		"big":               {input: "123456789012345678901/2", expected: "61728394506172839450 1/2"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			value := fraction(t, test.input)
+			assert.Equal(t, test.expected, value.ProperString())
+			assert.True(t, value.Equal(fraction(t, value.ProperString())))
+		})
+	}
+}
+
+func TestFractionJSON(t *testing.T) {
+	type price struct {
+		Ratio Fraction  `json:"ratio"`
+		Other *Fraction `json:"other"`
+	}
+
+	data, err := json.Marshal(price{Ratio: fraction(t, "7/2")})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"ratio":"7/2","other":null}`, string(data))
+
+	var decoded price
+	assert.Nil(t, json.Unmarshal([]byte(`{"ratio":"3 1/2","other":0.75}`), &decoded))
+	assert.Equal(t, "7/2", decoded.Ratio.String())
+	assert.Equal(t, "3/4", decoded.Other.String())
+
+	err = json.Unmarshal([]byte(`{"ratio":"1/0"}`), &decoded)
+	assert.ErrorIs(t, err, ErrDivideByZero)
+	err = json.Unmarshal([]byte(`{"ratio":true}`), &decoded)
+	assert.ErrorIs(t, err, ErrInvalidNumber)
+}
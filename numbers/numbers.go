@@ -0,0 +1,63 @@
+package numbers
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Converts a string to an int, returning the default value when the string
+// is not a decimal integer or does not fit in an int
+func ToInt(value string, defaultValue int) int {
+	result, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return defaultValue
+	}
+	return result
+}
+
+// Converts a string to an int64, returning the default value when the string
+// is not a decimal integer or does not fit in an int64
+func ToInt64(value string, defaultValue int64) int64 {
+	result, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return result
+}
+
+// Converts a string to a float64, returning the default value when the
+// string is not a number or is out of range
+func ToFloat64(value string, defaultValue float64) float64 {
+	result, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return defaultValue
+	}
+	return result
+}
+
+// Returns whether the string is made up only of the digits 0 to 9, the same
+// digits that strings.GetDigits keeps.  An empty string is not digits.
+func IsDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns whether the string is a plain decimal number: an optional minus
+// sign, digits, and optionally a decimal point followed by more digits.
+// Unlike IsCreatable, hexadecimal, exponents and type suffixes are not
+// accepted.
+func IsParsable(value string) bool {
+	value = strings.TrimPrefix(value, "-")
+	whole, fraction, hasPoint := strings.Cut(value, ".")
+	if !hasPoint {
+		return IsDigits(whole)
+	}
+	return IsDigits(fraction) && (whole == "" || IsDigits(whole))
+}
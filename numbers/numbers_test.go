@@ -0,0 +1,99 @@
+package numbers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToInt(t *testing.T) {
+	var tests = map[string]struct {
+		input    string
+		expected int
+	}{
+		"integer":           {input: "42", expected: 42},
+		"negative":          {input: "-7", expected: -7},
+		"surrounding space": {input: " 12 ", expected: 12},
+		"empty":             {input: "", expected: -1},
+		"decimal":           {input: "1.5", expected: -1},
+		"letters":           {input: "abc", expected: -1},
+		"too large":         {input: "99999999999999999999", expected: -1},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ToInt(test.input, -1))
+		})
+	}
+}
+
+func TestToInt64(t *testing.T) {
+	assert.Equal(t, int64(9007199254740993), ToInt64("9007199254740993", 0))
+	assert.Equal(t, int64(5), ToInt64("0x10", 5))
+}
+
+func TestToFloat64(t *testing.T) {
+	var tests = map[string]struct {
+		input    string
+		expected float64
+	}{
+		"decimal":    {input: "1.25", expected: 1.25},
+		"exponent":   {input: "2e3", expected: 2000},
+		"integer":    {input: "-3", expected: -3},
+		"empty":      {input: "", expected: 0.5},
+		"not number": {input: "1.2.3", expected: 0.5},
+		"too large":  {input: "1e400", expected: 0.5},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ToFloat64(test.input, 0.5))
+		})
+	}
+}
+
+func TestIsDigits(t *testing.T) {
+	var tests = map[string]struct {
+		input    string
+		expected bool
+	}{
+		"digits":        {input: "0123456789", expected: true},
+		"empty":         {input: "", expected: false},
+		"sign":          {input: "-1", expected: false},
+		"decimal":       {input: "1.0", expected: false},
+		"space":         {input: "1 2", expected: false},
+		"unicode digit": {input: "١٢", expected: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsDigits(test.input))
+		})
+	}
+}
+
+func TestIsParsable(t *testing.T) {
+	var tests = map[string]struct {
+		input    string
+		expected bool
+	}{
+		"integer":          {input: "123", expected: true},
+		"negative":         {input: "-123", expected: true},
+		"decimal":          {input: "1.5", expected: true},
+		"leading point":    {input: ".5", expected: true},
+		"negative decimal": {input: "-0.5", expected: true},
+		"empty":            {input: "", expected: false},
+		"minus only":       {input: "-", expected: false},
+		"trailing point":   {input: "5.", expected: false},
+		"two points":       {input: "1.2.3", expected: false},
+		"plus sign":        {input: "+1", expected: false},
+		"hexadecimal":      {input: "0x1F", expected: false},
+		"exponent":         {input: "1e5", expected: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsParsable(test.input))
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package objects
+
+import (
+	"reflect"
+)
+
+// Implemented by types that know how to copy themselves.  Clone calls the
+// Clone method of any value whose type has one returning that same type
+// instead of copying it field by field.
+type Cloner[T any] interface {
+	Clone() T
+}
+
+// Identifies a pointer, map or slice that has already been cloned.  Slices
+// are only shared when their length and capacity are the same as well, as
+// slices with a different capacity behave differently when appended to.
+type visit struct {
+	pointer  uintptr
+	typ      reflect.Type
+	length   int
+	capacity int
+}
+
+type cloner struct {
+	seen map[visit]reflect.Value
+}
+
+// Returns a deep copy of the value.  Pointers, slices, arrays, maps,
+// interfaces and the exported fields of structs are copied recursively, while
+// numbers, strings, channels and functions are copied as they are.  Values
+// that are shared, including those that refer back to themselves, are copied
+// once so the copy has the same shape as the original.
+//
+// The unexported fields of structs and the keys of maps are not cloned; they
+// are copied as they are and may still refer to the original values.  Types
+// whose fields are unexported can implement Cloner to copy them.
+func Clone[T any](value T) T {
+	c := cloner{seen: map[visit]reflect.Value{}}
+	result := new(T)
+	if copied := c.clone(reflect.ValueOf(&value).Elem()); copied.IsValid() {
+		reflect.ValueOf(result).Elem().Set(copied)
+	}
+	return *result
+}
+
+func (c *cloner) clone(value reflect.Value) reflect.Value {
+	if custom, ok := c.cloneMethod(value); ok {
+		return custom
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return value
+		}
+		key := visit{pointer: value.Pointer(), typ: value.Type()}
+		if copied, ok := c.seen[key]; ok {
+			return copied
+		}
+		copied := reflect.New(value.Type().Elem())
+		c.seen[key] = copied
+		copied.Elem().Set(c.clone(value.Elem()))
+		return copied
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(c.clone(value.Elem()))
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).IsExported() {
+				copied.Field(i).Set(c.clone(value.Field(i)))
+			}
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(value.Type()).Elem()
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(c.clone(value.Index(i)))
+		}
+		return copied
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		key := visit{pointer: value.Pointer(), typ: value.Type(), length: value.Len(), capacity: value.Cap()}
+		if copied, ok := c.seen[key]; ok {
+			return copied
+		}
+		copied := reflect.MakeSlice(value.Type(), value.Len(), value.Cap())
+		c.seen[key] = copied
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(c.clone(value.Index(i)))
+		}
+		return copied
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		key := visit{pointer: value.Pointer(), typ: value.Type()}
+		if copied, ok := c.seen[key]; ok {
+			return copied
+		}
+		copied := reflect.MakeMapWithSize(value.Type(), value.Len())
+		c.seen[key] = copied
+		iter := value.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), c.clone(iter.Value()))
+		}
+		return copied
+	}
+	return value
+}
+
+// Calls the Clone method of the value when its type has one returning the
+// same type
+func (c *cloner) cloneMethod(value reflect.Value) (reflect.Value, bool) {
+	if !value.IsValid() || !value.CanInterface() || value.Kind() == reflect.Interface {
+		return reflect.Value{}, false
+	}
+	if value.Kind() == reflect.Pointer && value.IsNil() {
+		return reflect.Value{}, false
+	}
+	method := value.MethodByName("Clone")
+	if !method.IsValid() {
+		return reflect.Value{}, false
+	}
+	signature := method.Type()
+	if signature.NumIn() != 0 || signature.NumOut() != 1 || signature.Out(0) != value.Type() {
+		return reflect.Value{}, false
+	}
+	return method.Call(nil)[0], true
+}
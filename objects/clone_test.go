@@ -0,0 +1,125 @@
+package objects
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	Lines []string
+	City  string
+}
+
+type person struct {
+	Name     string
+	Home     *address
+	Tags     map[string][]string
+	Scores   [2]int
+	Extra    any
+	Friend   *person
+	Born     time.Time
+	internal *address
+}
+
+type counter struct {
+	hits *int
+}
+
+func (c counter) Clone() counter {
+	hits := *c.hits
+	return counter{hits: &hits}
+}
+
+var _ Cloner[counter] = counter{}
+
+func TestClone(t *testing.T) {
+	home := &address{Lines: []string{"1 Main St"}, City: "Springfield"}
+	original := person{
+		Name:     "Ann",
+		Home:     home,
+		Tags:     map[string][]string{"team": {"core"}},
+		Scores:   [2]int{1, 2},
+		Extra:    &address{City: "Shelbyville"},
+		Born:     time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC),
+		internal: home,
+	}
+
+	copied := Clone(original)
+	assert.Equal(t, original, copied)
+
+	copied.Home.Lines[0] = "2 Oak Ave"
+	copied.Tags["team"][0] = "web"
+	copied.Extra.(*address).City = "Capital City"
+	assert.Equal(t, "1 Main St", original.Home.Lines[0])
+	assert.Equal(t, "core", original.Tags["team"][0])
+	assert.Equal(t, "Shelbyville", original.Extra.(*address).City)
+	assert.Same(t, original.internal, copied.internal, "unexported fields are copied as they are")
+}
+
+func TestCloneCycles(t *testing.T) {
+	ann := &person{Name: "Ann"}
+	bob := &person{Name: "Bob", Friend: ann}
+	ann.Friend = bob
+
+	copied := Clone(ann)
+	assert.NotSame(t, ann, copied)
+	assert.NotSame(t, bob, copied.Friend)
+	assert.Equal(t, "Bob", copied.Friend.Name)
+	assert.Same(t, copied, copied.Friend.Friend, "the cycle is preserved in the copy")
+
+	loop := []any{nil}
+	loop[0] = loop
+	copiedLoop := Clone(loop)
+	assert.NotSame(t, &loop[0], &copiedLoop[0])
+	assert.Same(t, &copiedLoop[0], &copiedLoop[0].([]any)[0])
+}
+
+func TestCloneSharedValues(t *testing.T) {
+	shared := &address{City: "Springfield"}
+	values := []*address{shared, shared}
+
+	copied := Clone(values)
+	assert.NotSame(t, shared, copied[0])
+	assert.Same(t, copied[0], copied[1], "values shared in the original are shared in the copy")
+}
+
+func TestCloneSlicesWithDifferentCapacity(t *testing.T) {
+	type pair struct {
+		A, B []int
+	}
+	backing := []int{1, 2, 3, 4}
+	original := pair{A: backing[:2], B: backing[:2:2]}
+
+	copied := Clone(original)
+	assert.Equal(t, original, copied)
+	assert.Equal(t, 4, cap(copied.A))
+	assert.Equal(t, 2, cap(copied.B))
+
+	// appending to B reallocates, as it does in the original, and leaves A alone
+	before := append([]int(nil), copied.A[:cap(copied.A)]...)
+	copied.B = append(copied.B, 9)
+	assert.Equal(t, before, copied.A[:cap(copied.A)])
+}
+
+func TestCloneUsesCloner(t *testing.T) {
+	hits := 3
+	original := map[string]counter{"a": {hits: &hits}}
+
+	copied := Clone(original)
+	*copied["a"].hits = 4
+	assert.Equal(t, 3, hits)
+}
+
+func TestCloneSimpleValues(t *testing.T) {
+	assert.Equal(t, 42, Clone(42))
+	assert.Equal(t, "text", Clone("text"))
+	assert.Nil(t, Clone[*person](nil))
+	assert.Nil(t, Clone[any](nil))
+	assert.Nil(t, Clone[map[string]int](nil))
+	assert.Equal(t, []int{}, Clone([]int{}))
+
+	channel := make(chan int)
+	assert.Equal(t, channel, Clone(channel), "channels are copied as they are")
+}
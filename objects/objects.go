@@ -0,0 +1,91 @@
+package objects
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/jwmajors81/golang-commons-lang/optional"
+	"github.com/jwmajors81/golang-commons-lang/sorted"
+	"golang.org/x/exp/constraints"
+)
+
+// Returns whether the value is nil, including typed nil pointers, maps,
+// slices, channels and functions stored in an interface
+func IsNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// Returns the value, or defaultValue when the value is nil
+func DefaultIfNil[T any](value T, defaultValue T) T {
+	if IsNil(value) {
+		return defaultValue
+	}
+	return value
+}
+
+// Returns an Optional of the first value that is not nil, or an empty
+// Optional when every value is nil
+func FirstNonNil[T any](values ...T) optional.Optional[T] {
+	for _, value := range values {
+		if !IsNil(value) {
+			return optional.Of(value)
+		}
+	}
+	return optional.Empty[T]()
+}
+
+// Returns whether none of the values are nil.  Returns true when no values
+// are provided.
+func AllNotNil(values ...any) bool {
+	for _, value := range values {
+		if IsNil(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns whether at least one of the values is not nil.  Returns false when
+// no values are provided.
+func AnyNotNil(values ...any) bool {
+	for _, value := range values {
+		if !IsNil(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Compares the values the pointers refer to, returning a negative number,
+// zero or a positive number.  A nil pointer is less than any other value, or
+// greater when nilGreater is true, and two nil pointers are equal.
+func Compare[T constraints.Ordered](a, b *T, nilGreater bool) int {
+	if nilGreater {
+		return sorted.NilsLast(sorted.NaturalOrder[T]())(a, b)
+	}
+	return sorted.NilsFirst(sorted.NaturalOrder[T]())(a, b)
+}
+
+// Returns the type of the value and the address it refers to, such as
+// "*config.Server@0xc000010000", ignoring any String method.  Values without
+// an address, such as numbers and structs, are written with only their type
+// and an empty string is returned for nil.
+func IdentityToString(value any) string {
+	if value == nil {
+		return ""
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return fmt.Sprintf("%T@%#x", value, rv.Pointer())
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,120 @@
+package objects
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jwmajors81/golang-commons-lang/optional"
+	"github.com/stretchr/testify/assert"
+)
+
+type named struct {
+	name string
+}
+
+func (n *named) String() string {
+	return n.name
+}
+
+func TestIsNil(t *testing.T) {
+	var nilPointer *int
+	var nilMap map[string]int
+	var nilSlice []int
+	var nilFunc func()
+	var nilStringer fmt.Stringer = (*named)(nil)
+
+	var tests = map[string]struct {
+		value    any
+		expected bool
+	}{
+		"nil":          {value: nil, expected: true},
+		"nil pointer":  {value: nilPointer, expected: true},
+		"nil map":      {value: nilMap, expected: true},
+		"nil slice":    {value: nilSlice, expected: true},
+		"nil func":     {value: nilFunc, expected: true},
+		"typed nil":    {value: nilStringer, expected: true},
+		"zero":         {value: 0, expected: false},
+		"empty string": {value: "", expected: false},
+		"empty slice":  {value: []int{}, expected: false},
+		"pointer":      {value: &named{}, expected: false},
+		"zero struct":  {value: named{}, expected: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsNil(test.value))
+		})
+	}
+}
+
+func TestDefaultIfNil(t *testing.T) {
+	fallback := &named{name: "fallback"}
+	assert.Same(t, fallback, DefaultIfNil(nil, fallback))
+	value := &named{name: "value"}
+	assert.Same(t, value, DefaultIfNil(value, fallback))
+
+	var stringer fmt.Stringer = (*named)(nil)
+	assert.Equal(t, "fallback", DefaultIfNil(stringer, fmt.Stringer(fallback)).String())
+
+	assert.Equal(t, []int{1}, DefaultIfNil(nil, []int{1}))
+	assert.Equal(t, []int{}, DefaultIfNil([]int{}, []int{1}))
+	assert.Equal(t, 0, DefaultIfNil(0, 5), "values that cannot be nil are returned as they are")
+}
+
+func TestFirstNonNil(t *testing.T) {
+	second := &named{name: "second"}
+	assert.Equal(t, optional.Of(second), FirstNonNil(nil, second, &named{name: "third"}))
+	assert.Equal(t, optional.Empty[*named](), FirstNonNil[*named](nil, nil))
+	assert.Equal(t, optional.Empty[error](), FirstNonNil[error]())
+	assert.Equal(t, optional.Of[any](0), FirstNonNil[any](nil, 0))
+}
+
+func TestAllAndAnyNotNil(t *testing.T) {
+	var nilPointer *int
+	assert.True(t, AllNotNil(1, "", &named{}))
+	assert.False(t, AllNotNil(1, nilPointer))
+	assert.True(t, AllNotNil())
+
+	assert.True(t, AnyNotNil(nil, nilPointer, 0))
+	assert.False(t, AnyNotNil(nil, nilPointer))
+	assert.False(t, AnyNotNil())
+}
+
+func TestCompare(t *testing.T) {
+	one, two := 1, 2
+	var tests = map[string]struct {
+		a, b       *int
+		nilGreater bool
+		expected   int
+	}{
+		"less":              {a: &one, b: &two, expected: -1},
+		"greater":           {a: &two, b: &one, expected: 1},
+		"equal":             {a: &one, b: &one, expected: 0},
+		"both nil":          {expected: 0},
+		"nil first":         {a: nil, b: &one, expected: -1},
+		"nil last":          {a: nil, b: &one, nilGreater: true, expected: 1},
+		"other nil first":   {a: &one, b: nil, expected: 1},
+		"other nil last":    {a: &one, b: nil, nilGreater: true, expected: -1},
+		"both nil and last": {nilGreater: true, expected: 0},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Compare(test.a, test.b, test.nilGreater))
+		})
+	}
+}
+
+func TestIdentityToString(t *testing.T) {
+	value := &named{name: "ignored"}
+	expected := fmt.Sprintf("*objects.named@%p", value)
+	assert.Equal(t, expected, IdentityToString(value))
+	assert.NotContains(t, IdentityToString(value), "ignored")
+
+	assert.True(t, strings.HasPrefix(IdentityToString(map[string]int{}), "map[string]int@0x"))
+	assert.Equal(t, "int", IdentityToString(42))
+	assert.Equal(t, "objects.named", IdentityToString(named{}))
+	assert.Equal(t, "", IdentityToString(nil))
+	assert.NotEqual(t, IdentityToString(&named{}), IdentityToString(&named{}))
+}
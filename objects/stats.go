@@ -0,0 +1,48 @@
+package objects
+
+import (
+	"github.com/jwmajors81/golang-commons-lang/optional"
+	"github.com/jwmajors81/golang-commons-lang/sorted"
+	"golang.org/x/exp/constraints"
+)
+
+// Returns the middle value in sorted order, or the lower of the two middle
+// values when there is an even number of values.  The values are not
+// averaged, so the median is always one of the values provided.  Returns an
+// empty Optional when no values are provided.
+func Median[T constraints.Ordered](values ...T) optional.Optional[T] {
+	return MedianWith(sorted.NaturalOrder[T](), values...)
+}
+
+// Returns the median of the values ordered by the comparator, as described
+// by Median.  The values provided are not reordered.
+func MedianWith[T any](cmp sorted.Comparator[T], values ...T) optional.Optional[T] {
+	if len(values) == 0 {
+		return optional.Empty[T]()
+	}
+	ordered := make([]T, len(values))
+	copy(ordered, values)
+	cmp.Sort(ordered)
+	return optional.Of(ordered[(len(ordered)-1)/2])
+}
+
+// Returns the value that occurs most often.  Returns an empty Optional when
+// no values are provided or when more than one value occurs most often.
+func Mode[T comparable](values ...T) optional.Optional[T] {
+	counts := make(map[T]int, len(values))
+	var mode T
+	highest, tied := 0, false
+	for _, value := range values {
+		counts[value]++
+		switch count := counts[value]; {
+		case count > highest:
+			mode, highest, tied = value, count, false
+		case count == highest:
+			tied = true
+		}
+	}
+	if highest == 0 || tied {
+		return optional.Empty[T]()
+	}
+	return optional.Of(mode)
+}
@@ -0,0 +1,50 @@
+package objects
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jwmajors81/golang-commons-lang/optional"
+	"github.com/jwmajors81/golang-commons-lang/sorted"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMedian(t *testing.T) {
+	assert.Equal(t, optional.Of(3), Median(5, 1, 3))
+	assert.Equal(t, optional.Of(2), Median(4, 1, 3, 2), "the lower middle value is used for an even count")
+	assert.Equal(t, optional.Of("b"), Median("c", "a", "b"))
+	assert.Equal(t, optional.Of(7), Median(7))
+	assert.Equal(t, optional.Empty[int](), Median[int]())
+
+	values := []int{3, 1, 2}
+	Median(values...)
+	assert.Equal(t, []int{3, 1, 2}, values, "the values are not reordered")
+}
+
+func TestMedianWith(t *testing.T) {
+	byLength := sorted.Comparing(func(value string) int { return len(value) })
+	assert.Equal(t, optional.Of("bb"), MedianWith(byLength, "ccc", "a", "bb"))
+
+	caseInsensitive := sorted.ComparingWith(strings.ToLower, sorted.NaturalOrder[string]())
+	assert.Equal(t, optional.Of("B"), MedianWith(caseInsensitive, "c", "B", "a"))
+}
+
+func TestMode(t *testing.T) {
+	var tests = map[string]struct {
+		values   []string
+		expected optional.Optional[string]
+	}{
+		"single mode":     {values: []string{"a", "b", "b", "c"}, expected: optional.Of("b")},
+		"mode found late": {values: []string{"a", "b", "b", "a", "a"}, expected: optional.Of("a")},
+		"tie":             {values: []string{"a", "b", "b", "a"}, expected: optional.Empty[string]()},
+		"all different":   {values: []string{"a", "b"}, expected: optional.Empty[string]()},
+		"one value":       {values: []string{"a"}, expected: optional.Of("a")},
+		"no values":       {values: nil, expected: optional.Empty[string]()},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Mode(test.values...))
+		})
+	}
+}
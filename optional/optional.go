@@ -0,0 +1,171 @@
+package optional
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// A value that may or may not be present.  Unlike a pointer, an Optional
+// distinguishes a missing value from the zero value without aliasing the
+// value.  The zero value is an empty Optional.
+//
+// An empty Optional is marshaled to JSON as null and null is unmarshaled as
+// an empty Optional.  Optionals can also be read from and written to SQL
+// columns that allow NULL.
+type Optional[T any] struct {
+	value   T
+	present bool
+}
+
+// Returns an Optional containing the value
+func Of[T any](value T) Optional[T] {
+	return Optional[T]{value: value, present: true}
+}
+
+// Returns an empty Optional
+func Empty[T any]() Optional[T] {
+	return Optional[T]{}
+}
+
+// Returns an Optional containing the value the pointer refers to, or an
+// empty Optional when the pointer is nil
+func OfPointer[T any](value *T) Optional[T] {
+	if value == nil {
+		return Empty[T]()
+	}
+	return Of(*value)
+}
+
+// Returns whether a value is present
+func (o Optional[T]) IsPresent() bool {
+	return o.present
+}
+
+// Returns whether the Optional is empty
+func (o Optional[T]) IsEmpty() bool {
+	return !o.present
+}
+
+// Returns the value and whether it is present.  The zero value is returned
+// when the Optional is empty.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.present
+}
+
+// Returns the value, or other when the Optional is empty
+func (o Optional[T]) OrElse(other T) T {
+	if o.present {
+		return o.value
+	}
+	return other
+}
+
+// Returns the value, or the result of calling supplier when the Optional is
+// empty.  The supplier is only called when it is needed.
+func (o Optional[T]) OrElseGet(supplier func() T) T {
+	if o.present {
+		return o.value
+	}
+	return supplier()
+}
+
+// Returns the value, or the error when the Optional is empty
+func (o Optional[T]) OrElseErr(err error) (T, error) {
+	if o.present {
+		return o.value, nil
+	}
+	var zero T
+	return zero, err
+}
+
+// Calls the action with the value when it is present
+func (o Optional[T]) IfPresent(action func(T)) {
+	if o.present {
+		action(o.value)
+	}
+}
+
+// Calls the action with the value when it is present, otherwise calls
+// emptyAction
+func (o Optional[T]) IfPresentOrElse(action func(T), emptyAction func()) {
+	if o.present {
+		action(o.value)
+	} else {
+		emptyAction()
+	}
+}
+
+// Returns the Optional when its value is present and matches the predicate,
+// otherwise an empty Optional
+func (o Optional[T]) Filter(predicate func(T) bool) Optional[T] {
+	if o.present && predicate(o.value) {
+		return o
+	}
+	return Empty[T]()
+}
+
+// Returns the Optional when its value is present, otherwise the result of
+// calling supplier
+func (o Optional[T]) Or(supplier func() Optional[T]) Optional[T] {
+	if o.present {
+		return o
+	}
+	return supplier()
+}
+
+// Returns a pointer to a copy of the value, or nil when the Optional is empty
+func (o Optional[T]) Ptr() *T {
+	if !o.present {
+		return nil
+	}
+	value := o.value
+	return &value
+}
+
+// Returns "Optional[value]" or "Optional.empty"
+func (o Optional[T]) String() string {
+	if !o.present {
+		return "Optional.empty"
+	}
+	return fmt.Sprintf("Optional[%v]", o.value)
+}
+
+// Marshals the value, or null when the Optional is empty
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.present {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// Unmarshals null as an empty Optional and any other value as a present one
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = Empty[T]()
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*o = Of(value)
+	return nil
+}
+
+// Applies the mapper to the value when it is present, returning an Optional
+// of the result, or an empty Optional when o is empty
+func Map[T, U any](o Optional[T], mapper func(T) U) Optional[U] {
+	if !o.present {
+		return Empty[U]()
+	}
+	return Of(mapper(o.value))
+}
+
+// Applies the mapper to the value when it is present, returning its result
+// without wrapping it, or an empty Optional when o is empty
+func FlatMap[T, U any](o Optional[T], mapper func(T) Optional[U]) Optional[U] {
+	if !o.present {
+		return Empty[U]()
+	}
+	return mapper(o.value)
+}
@@ -0,0 +1,137 @@
+package optional
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOfAndEmpty(t *testing.T) {
+	present := Of(0)
+	assert.True(t, present.IsPresent())
+	assert.False(t, present.IsEmpty())
+	value, ok := present.Get()
+	assert.Equal(t, 0, value)
+	assert.True(t, ok)
+
+	empty := Empty[int]()
+	assert.False(t, empty.IsPresent())
+	assert.True(t, empty.IsEmpty())
+	value, ok = empty.Get()
+	assert.Equal(t, 0, value)
+	assert.False(t, ok)
+
+	var zero Optional[string]
+	assert.True(t, zero.IsEmpty())
+	assert.Equal(t, Empty[string](), zero)
+}
+
+func TestOfPointer(t *testing.T) {
+	name := "gopher"
+	assert.Equal(t, Of("gopher"), OfPointer(&name))
+	assert.Equal(t, Empty[string](), OfPointer[string](nil))
+}
+
+func TestOrElse(t *testing.T) {
+	calls := 0
+	supplier := func() string {
+		calls++
+		return "default"
+	}
+
+	assert.Equal(t, "value", Of("value").OrElse("default"))
+	assert.Equal(t, "default", Empty[string]().OrElse("default"))
+	assert.Equal(t, "value", Of("value").OrElseGet(supplier))
+	assert.Equal(t, 0, calls, "the supplier is not called when a value is present")
+	assert.Equal(t, "default", Empty[string]().OrElseGet(supplier))
+	assert.Equal(t, 1, calls)
+
+	notFound := errors.New("not found")
+	value, err := Of("value").OrElseErr(notFound)
+	assert.Equal(t, "value", value)
+	assert.Nil(t, err)
+	value, err = Empty[string]().OrElseErr(notFound)
+	assert.Equal(t, "", value)
+	assert.Equal(t, notFound, err)
+}
+
+func TestOr(t *testing.T) {
+	fallback := func() Optional[int] { return Of(2) }
+	assert.Equal(t, Of(1), Of(1).Or(fallback))
+	assert.Equal(t, Of(2), Empty[int]().Or(fallback))
+}
+
+func TestIfPresent(t *testing.T) {
+	var seen []string
+	Of("a").IfPresent(func(value string) { seen = append(seen, value) })
+	Empty[string]().IfPresent(func(value string) { seen = append(seen, value) })
+	assert.Equal(t, []string{"a"}, seen)
+
+	Of("b").IfPresentOrElse(func(value string) { seen = append(seen, value) }, func() { seen = append(seen, "empty") })
+	Empty[string]().IfPresentOrElse(func(value string) { seen = append(seen, value) }, func() { seen = append(seen, "empty") })
+	assert.Equal(t, []string{"a", "b", "empty"}, seen)
+}
+
+func TestFilter(t *testing.T) {
+	even := func(value int) bool { return value%2 == 0 }
+	assert.Equal(t, Of(2), Of(2).Filter(even))
+	assert.Equal(t, Empty[int](), Of(3).Filter(even))
+	assert.Equal(t, Empty[int](), Empty[int]().Filter(even))
+}
+
+func TestMapAndFlatMap(t *testing.T) {
+	assert.Equal(t, Of("42"), Map(Of(42), strconv.Itoa))
+	assert.Equal(t, Empty[string](), Map(Empty[int](), strconv.Itoa))
+
+	parse := func(value string) Optional[int] {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return Empty[int]()
+		}
+		return Of(parsed)
+	}
+	assert.Equal(t, Of(42), FlatMap(Of("42"), parse))
+	assert.Equal(t, Empty[int](), FlatMap(Of("forty two"), parse))
+	assert.Equal(t, Empty[int](), FlatMap(Empty[string](), parse))
+}
+
+func TestPtr(t *testing.T) {
+	present := Of(5)
+	pointer := present.Ptr()
+	*pointer = 6
+	assert.Equal(t, Of(5), present, "the pointer refers to a copy")
+	assert.Nil(t, Empty[int]().Ptr())
+}
+
+func TestString(t *testing.T) {
+	assert.Equal(t, "Optional[42]", Of(42).String())
+	assert.Equal(t, "Optional[]", Of("").String())
+	assert.Equal(t, "Optional.empty", Empty[int]().String())
+}
+
+func TestJSON(t *testing.T) {
+	type settings struct {
+		Timeout Optional[int]      `json:"timeout"`
+		Name    Optional[string]   `json:"name"`
+		Tags    Optional[[]string] `json:"tags"`
+	}
+
+	data, err := json.Marshal(settings{Timeout: Of(0), Tags: Of([]string{"a"})})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"timeout":0,"name":null,"tags":["a"]}`, string(data))
+
+	var decoded settings
+	assert.Nil(t, json.Unmarshal([]byte(`{"timeout":30,"name":null}`), &decoded))
+	assert.Equal(t, Of(30), decoded.Timeout)
+	assert.Equal(t, Empty[string](), decoded.Name)
+	assert.Equal(t, Empty[[]string](), decoded.Tags)
+
+	existing := settings{Name: Of("old")}
+	assert.Nil(t, json.Unmarshal([]byte(`{"name":null}`), &existing))
+	assert.Equal(t, Empty[string](), existing.Name)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"timeout":"soon"}`), &decoded))
+}
@@ -0,0 +1,150 @@
+package optional
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Reads a column that may be NULL, implementing sql.Scanner.  NULL results
+// in an empty Optional.  Other values are scanned by the value's own Scan
+// method when *T implements sql.Scanner, or converted between the numeric,
+// boolean, string and []byte types drivers return and T.
+func (o *Optional[T]) Scan(src any) error {
+	if src == nil {
+		*o = Empty[T]()
+		return nil
+	}
+
+	var value T
+	if scanner, ok := any(&value).(sql.Scanner); ok {
+		if err := scanner.Scan(src); err != nil {
+			return err
+		}
+		*o = Of(value)
+		return nil
+	}
+	if err := assign(reflect.ValueOf(&value).Elem(), src); err != nil {
+		return err
+	}
+	*o = Of(value)
+	return nil
+}
+
+// Writes the value, or NULL when the Optional is empty, implementing
+// driver.Valuer
+func (o Optional[T]) Value() (driver.Value, error) {
+	if !o.present {
+		return nil, nil
+	}
+	if valuer, ok := any(o.value).(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.value)
+}
+
+// Stores the value returned by a driver in the target, converting it when
+// the types differ.  A []byte is copied because drivers may reuse it.
+func assign(target reflect.Value, src any) error {
+	if raw, ok := src.([]byte); ok {
+		copied := make([]byte, len(raw))
+		copy(copied, raw)
+		src = copied
+	}
+	source := reflect.ValueOf(src)
+	if source.Type().AssignableTo(target.Type()) {
+		target.Set(source)
+		return nil
+	}
+
+	text, isText := src.(string)
+	if raw, ok := src.([]byte); ok {
+		text, isText = string(raw), true
+	}
+	failed := fmt.Errorf("cannot scan %T into %s", src, target.Type())
+
+	switch target.Kind() {
+	case reflect.String:
+		if isText {
+			target.SetString(text)
+			return nil
+		}
+		if source.CanInt() || source.CanUint() || source.CanFloat() || source.Kind() == reflect.Bool {
+			target.SetString(fmt.Sprint(src))
+			return nil
+		}
+	case reflect.Slice:
+		if isText && target.Type().Elem().Kind() == reflect.Uint8 {
+			target.SetBytes([]byte(text))
+			return nil
+		}
+	case reflect.Bool:
+		if isText {
+			parsed, err := strconv.ParseBool(text)
+			if err != nil {
+				return fmt.Errorf("%v: %w", failed, err)
+			}
+			target.SetBool(parsed)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if isText {
+			parsed, err := strconv.ParseInt(text, 10, target.Type().Bits())
+			if err != nil {
+				return fmt.Errorf("%v: %w", failed, err)
+			}
+			target.SetInt(parsed)
+			return nil
+		}
+		if source.CanInt() && !target.OverflowInt(source.Int()) {
+			target.SetInt(source.Int())
+			return nil
+		}
+		if source.CanUint() && source.Uint() <= 1<<63-1 && !target.OverflowInt(int64(source.Uint())) {
+			target.SetInt(int64(source.Uint()))
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if isText {
+			parsed, err := strconv.ParseUint(text, 10, target.Type().Bits())
+			if err != nil {
+				return fmt.Errorf("%v: %w", failed, err)
+			}
+			target.SetUint(parsed)
+			return nil
+		}
+		if source.CanInt() && source.Int() >= 0 && !target.OverflowUint(uint64(source.Int())) {
+			target.SetUint(uint64(source.Int()))
+			return nil
+		}
+		if source.CanUint() && !target.OverflowUint(source.Uint()) {
+			target.SetUint(source.Uint())
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if isText {
+			parsed, err := strconv.ParseFloat(text, target.Type().Bits())
+			if err != nil {
+				return fmt.Errorf("%v: %w", failed, err)
+			}
+			target.SetFloat(parsed)
+			return nil
+		}
+		if source.CanFloat() {
+			target.SetFloat(source.Float())
+			return nil
+		}
+		if source.CanInt() {
+			target.SetFloat(float64(source.Int()))
+			return nil
+		}
+	}
+
+	if source.Type().ConvertibleTo(target.Type()) && source.Kind() == target.Kind() {
+		target.Set(source.Convert(target.Type()))
+		return nil
+	}
+	return failed
+}
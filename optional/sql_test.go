@@ -0,0 +1,122 @@
+package optional
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type status string
+
+func TestScan(t *testing.T) {
+	created := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var tests = map[string]struct {
+		scan     func() (any, error)
+		expected any
+	}{
+		"null":             {scan: scanInto[int](nil), expected: Empty[int]()},
+		"same type":        {scan: scanInto[int64](int64(7)), expected: Of(int64(7))},
+		"narrower integer": {scan: scanInto[int32](int64(7)), expected: Of(int32(7))},
+		"unsigned":         {scan: scanInto[uint8](int64(255)), expected: Of(uint8(255))},
+		"integer text":     {scan: scanInto[int]([]byte("42")), expected: Of(42)},
+		"float":            {scan: scanInto[float32](float64(1.5)), expected: Of(float32(1.5))},
+		"float text":       {scan: scanInto[float64]("2.25"), expected: Of(2.25)},
+		"integer to float": {scan: scanInto[float64](int64(3)), expected: Of(3.0)},
+		"bytes to string":  {scan: scanInto[string]([]byte("name")), expected: Of("name")},
+		"string to bytes":  {scan: scanInto[[]byte]("raw"), expected: Of([]byte("raw"))},
+		"number to string": {scan: scanInto[string](int64(12)), expected: Of("12")},
+		"bool":             {scan: scanInto[bool](true), expected: Of(true)},
+		"bool text":        {scan: scanInto[bool]("1"), expected: Of(true)},
+		"time":             {scan: scanInto[time.Time](created), expected: Of(created)},
+		"named string":     {scan: scanInto[status]("active"), expected: Of(status("active"))},
+		"named from bytes": {scan: scanInto[status]([]byte("active")), expected: Of(status("active"))},
+		"scanner":          {scan: scanInto[sql.NullInt64](int64(9)), expected: Of(sql.NullInt64{Int64: 9, Valid: true})},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := test.scan()
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func scanInto[T any](src any) func() (any, error) {
+	return func() (any, error) {
+		result := Of(*new(T))
+		err := result.Scan(src)
+		return result, err
+	}
+}
+
+func TestScanErrors(t *testing.T) {
+	var tests = map[string]struct {
+		scan            func() (any, error)
+		expectedMessage string
+	}{
+		"overflow":         {scan: scanInto[int8](int64(300)), expectedMessage: "cannot scan int64 into int8"},
+		"negative to uint": {scan: scanInto[uint](int64(-1)), expectedMessage: "cannot scan int64 into uint"},
+		"bad integer text": {scan: scanInto[int]("abc"), expectedMessage: `cannot scan string into int: strconv.ParseInt: parsing "abc": invalid syntax`},
+		"bad bool text":    {scan: scanInto[bool]([]byte("maybe")), expectedMessage: `cannot scan []uint8 into bool: strconv.ParseBool: parsing "maybe": invalid syntax`},
+		"time to int":      {scan: scanInto[int](time.Now()), expectedMessage: "cannot scan time.Time into int"},
+		"float to int":     {scan: scanInto[int](1.5), expectedMessage: "cannot scan float64 into int"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := test.scan()
+			assert.EqualError(t, err, test.expectedMessage)
+		})
+	}
+}
+
+func TestScanErrorLeavesValue(t *testing.T) {
+	value := Of(int8(1))
+	assert.Error(t, value.Scan(int64(300)))
+	assert.Equal(t, Of(int8(1)), value)
+}
+
+func TestScanCopiesBytes(t *testing.T) {
+	type raw []byte
+	buffer := []byte("abc")
+
+	var bytes Optional[[]byte]
+	var value Optional[any]
+	var named Optional[raw]
+	assert.Nil(t, bytes.Scan(buffer))
+	assert.Nil(t, value.Scan(buffer))
+	assert.Nil(t, named.Scan(buffer))
+
+	// drivers may reuse the buffer for the next row
+	copy(buffer, "xyz")
+	assert.Equal(t, Of([]byte("abc")), bytes)
+	assert.Equal(t, Of[any]([]byte("abc")), value)
+	assert.Equal(t, Of(raw("abc")), named)
+}
+
+func TestValue(t *testing.T) {
+	var tests = map[string]struct {
+		valuer   driver.Valuer
+		expected driver.Value
+	}{
+		"empty":        {valuer: Empty[int](), expected: nil},
+		"integer":      {valuer: Of(int32(7)), expected: int64(7)},
+		"string":       {valuer: Of("name"), expected: "name"},
+		"named string": {valuer: Of(status("active")), expected: "active"},
+		"valuer":       {valuer: Of(sql.NullString{String: "x", Valid: true}), expected: "x"},
+		"null valuer":  {valuer: Of(sql.NullString{}), expected: nil},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := test.valuer.Value()
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package optional
+
+// Returns an Optional of the first value that is not the zero value, or an
+// empty Optional when every value is the zero value
+func FirstNonZero[T comparable](values ...T) Optional[T] {
+	var zero T
+	for _, value := range values {
+		if value != zero {
+			return Of(value)
+		}
+	}
+	return Empty[T]()
+}
+
+// Returns the first value that is not the zero value, or the zero value when
+// there is none, like the SQL COALESCE function
+func Coalesce[T comparable](values ...T) T {
+	var zero T
+	return FirstNonZero(values...).OrElse(zero)
+}
+
+// Returns the value, or defaultValue when the value is the zero value
+func DefaultIfZero[T comparable](value T, defaultValue T) T {
+	var zero T
+	if value == zero {
+		return defaultValue
+	}
+	return value
+}
+
+// Returns whether the value is the zero value of its type
+func IsZero[T comparable](value T) bool {
+	var zero T
+	return value == zero
+}
+
+// Returns the value the pointer refers to, or the zero value when the
+// pointer is nil
+func Deref[T any](value *T) T {
+	var zero T
+	return DerefOr(value, zero)
+}
+
+// Returns the value the pointer refers to, or defaultValue when the pointer
+// is nil
+func DerefOr[T any](value *T, defaultValue T) T {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
+
+// Returns a pointer to a copy of the value, which is useful for literals
+// such as Ptr(30) in struct fields that are pointers
+func Ptr[T any](value T) *T {
+	return &value
+}
@@ -0,0 +1,53 @@
+package optional
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFirstNonZero(t *testing.T) {
+	assert.Equal(t, Of("b"), FirstNonZero("", "b", "c"))
+	assert.Equal(t, Of(3), FirstNonZero(0, 0, 3))
+	assert.Equal(t, Empty[int](), FirstNonZero(0, 0))
+	assert.Equal(t, Empty[string](), FirstNonZero[string]())
+
+	type point struct{ X, Y int }
+	assert.Equal(t, Of(point{Y: 1}), FirstNonZero(point{}, point{Y: 1}))
+}
+
+func TestCoalesce(t *testing.T) {
+	assert.Equal(t, "fallback", Coalesce("", "fallback"))
+	assert.Equal(t, 0.5, Coalesce(0, 0.5, 1))
+	assert.Equal(t, "", Coalesce("", ""))
+
+	one := 1
+	assert.Equal(t, &one, Coalesce(nil, &one))
+}
+
+func TestDefaultIfZero(t *testing.T) {
+	assert.Equal(t, 8080, DefaultIfZero(0, 8080))
+	assert.Equal(t, 443, DefaultIfZero(443, 8080))
+	assert.Equal(t, "localhost", DefaultIfZero("", "localhost"))
+}
+
+func TestIsZero(t *testing.T) {
+	assert.True(t, IsZero(0))
+	assert.True(t, IsZero(""))
+	assert.False(t, IsZero(true))
+	assert.False(t, IsZero(" "))
+}
+
+func TestDeref(t *testing.T) {
+	count := 3
+	assert.Equal(t, 3, Deref(&count))
+	assert.Equal(t, 0, Deref[int](nil))
+	assert.Equal(t, 3, DerefOr(&count, 10))
+	assert.Equal(t, 10, DerefOr(nil, 10))
+}
+
+func TestPtrHelper(t *testing.T) {
+	pointer := Ptr(30)
+	assert.Equal(t, 30, *pointer)
+	assert.NotSame(t, Ptr(30), pointer)
+}
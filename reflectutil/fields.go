@@ -0,0 +1,171 @@
+package reflectutil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	// Returned when a field, map key or method does not exist
+	ErrNotFound = errors.New("not found")
+	// Returned when a value is not of the kind an operation requires, such as
+	// listing the fields of something other than a struct
+	ErrInvalidType = errors.New("invalid type")
+)
+
+// Describes an exported field of a struct, including fields promoted from
+// embedded structs
+type FieldInfo struct {
+	// The name of the field
+	Name string
+	// The names of the embedded structs leading to the field and its own
+	// name, separated by dots, such as "Base.ID" for a promoted field
+	Path string
+	// The index sequence for reflect.Value.FieldByIndex
+	Index []int
+	// The type of the field
+	Type reflect.Type
+	// The tag of the field
+	Tag reflect.StructTag
+	// Whether the field is an embedded struct
+	Embedded bool
+}
+
+// Returns the value of the tag with the key and whether it is present
+func (f FieldInfo) Lookup(key string) (string, bool) {
+	return f.Tag.Lookup(key)
+}
+
+// Returns the name of the tag with the key, the text before the first comma,
+// and its comma separated options.  For `json:"id,omitempty"` TagName("json")
+// returns "id" and ["omitempty"].
+func (f FieldInfo) TagName(key string) (string, []string) {
+	tag, ok := f.Tag.Lookup(key)
+	if !ok {
+		return "", nil
+	}
+	name, rest, hasOptions := strings.Cut(tag, ",")
+	if !hasOptions {
+		return name, nil
+	}
+	return name, strings.Split(rest, ",")
+}
+
+// The cached metadata of a struct type
+type typeInfo struct {
+	fields []FieldInfo
+	byName map[string]FieldInfo
+}
+
+var types sync.Map
+
+// Returns the metadata of the struct type, creating and caching it on first use
+func infoOf(t reflect.Type) *typeInfo {
+	if cached, ok := types.Load(t); ok {
+		return cached.(*typeInfo)
+	}
+
+	info := &typeInfo{byName: map[string]FieldInfo{}}
+	paths := map[string]string{}
+	for _, field := range reflect.VisibleFields(t) {
+		// Visible fields are listed after the embedded struct they belong to,
+		// so the path of the parent is always known
+		parent := paths[fmt.Sprint(field.Index[:len(field.Index)-1])]
+		path := field.Name
+		if parent != "" {
+			path = parent + "." + field.Name
+		}
+		paths[fmt.Sprint(field.Index)] = path
+
+		if !field.IsExported() {
+			continue
+		}
+		fieldInfo := FieldInfo{
+			Name:     field.Name,
+			Path:     path,
+			Index:    field.Index,
+			Type:     field.Type,
+			Tag:      field.Tag,
+			Embedded: field.Anonymous,
+		}
+		info.fields = append(info.fields, fieldInfo)
+		info.byName[field.Name] = fieldInfo
+	}
+
+	cached, _ := types.LoadOrStore(t, info)
+	return cached.(*typeInfo)
+}
+
+// Returns the struct type of a struct, pointer to a struct or reflect.Type
+func structType(value any) (reflect.Type, error) {
+	t, ok := value.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(value)
+	}
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %v is not a struct", ErrInvalidType, t)
+	}
+	return t, nil
+}
+
+// Returns the exported fields of a struct, a pointer to a struct or a struct
+// reflect.Type, in declaration order.  The fields of embedded structs are
+// listed after the embedded struct itself, as encoding/json would promote
+// them; fields hidden by a field of the same name at a shallower depth are
+// left out.  The metadata is cached per type.
+func Fields(value any) ([]FieldInfo, error) {
+	t, err := structType(value)
+	if err != nil {
+		return nil, err
+	}
+	fields := infoOf(t).fields
+	result := make([]FieldInfo, len(fields))
+	copy(result, fields)
+	return result, nil
+}
+
+// Returns the exported field with the name, which may be promoted from an
+// embedded struct
+func Field(value any, name string) (FieldInfo, error) {
+	t, err := structType(value)
+	if err != nil {
+		return FieldInfo{}, err
+	}
+	field, ok := infoOf(t).byName[name]
+	if !ok {
+		return FieldInfo{}, fmt.Errorf("%w: field %s of %s", ErrNotFound, name, t)
+	}
+	return field, nil
+}
+
+// Returns the exported fields that have a tag with the key
+func FieldsWithTag(value any, key string) ([]FieldInfo, error) {
+	fields, err := Fields(value)
+	if err != nil {
+		return nil, err
+	}
+	var result []FieldInfo
+	for _, field := range fields {
+		if _, ok := field.Tag.Lookup(key); ok {
+			result = append(result, field)
+		}
+	}
+	return result, nil
+}
+
+// Returns the value of the tag with the key on the named field and whether
+// the tag is present
+func LookupTag(value any, name string, key string) (string, bool, error) {
+	field, err := Field(value, name)
+	if err != nil {
+		return "", false, err
+	}
+	tag, ok := field.Tag.Lookup(key)
+	return tag, ok, nil
+}
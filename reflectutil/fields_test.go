@@ -0,0 +1,121 @@
+package reflectutil
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Base struct {
+	ID      int    `json:"id" db:"id"`
+	Created string `json:"created,omitempty"`
+}
+
+type audit struct {
+	Editor string `db:"editor"`
+	secret string
+}
+
+type record struct {
+	Base
+	*audit
+	Name   string `json:"name" db:"name"`
+	ID     string `json:"record_id"`
+	hidden int
+}
+
+func TestFields(t *testing.T) {
+	fields, err := Fields(record{})
+	assert.Nil(t, err)
+
+	var paths []string
+	for _, field := range fields {
+		paths = append(paths, field.Path)
+	}
+	assert.Equal(t, []string{"Base", "Base.Created", "audit.Editor", "Name", "ID"}, paths)
+
+	assert.Equal(t, "Base", fields[0].Name)
+	assert.True(t, fields[0].Embedded)
+	assert.Equal(t, []int{0, 1}, fields[1].Index)
+	assert.Equal(t, reflect.TypeOf(""), fields[1].Type)
+	assert.False(t, fields[3].Embedded)
+	assert.Equal(t, "record_id", fields[4].Tag.Get("json"), "the shallower ID hides the promoted one")
+}
+
+func TestFieldsAcceptsPointersAndTypes(t *testing.T) {
+	fromValue, err := Fields(record{})
+	assert.Nil(t, err)
+	fromPointer, err := Fields(&record{})
+	assert.Nil(t, err)
+	fromType, err := Fields(reflect.TypeOf(record{}))
+	assert.Nil(t, err)
+	assert.Equal(t, fromValue, fromPointer)
+	assert.Equal(t, fromValue, fromType)
+
+	fromValue[0].Name = "changed"
+	again, _ := Fields(record{})
+	assert.Equal(t, "Base", again[0].Name, "the cached fields cannot be modified")
+}
+
+func TestFieldsOfNonStruct(t *testing.T) {
+	_, err := Fields(42)
+	assert.EqualError(t, err, "invalid type: int is not a struct")
+	assert.ErrorIs(t, err, ErrInvalidType)
+
+	_, err = Fields(nil)
+	assert.ErrorIs(t, err, ErrInvalidType)
+}
+
+func TestField(t *testing.T) {
+	field, err := Field(record{}, "Editor")
+	assert.Nil(t, err)
+	assert.Equal(t, "audit.Editor", field.Path)
+	assert.Equal(t, []int{1, 0}, field.Index)
+
+	_, err = Field(record{}, "hidden")
+	assert.EqualError(t, err, "not found: field hidden of reflectutil.record")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestTags(t *testing.T) {
+	withDB, err := FieldsWithTag(&record{}, "db")
+	assert.Nil(t, err)
+	var names []string
+	for _, field := range withDB {
+		names = append(names, field.Name)
+	}
+	assert.Equal(t, []string{"Editor", "Name"}, names)
+
+	tag, ok, err := LookupTag(record{}, "Name", "json")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "name", tag)
+
+	_, ok, err = LookupTag(record{}, "Name", "xml")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	_, _, err = LookupTag(record{}, "Missing", "json")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestTagName(t *testing.T) {
+	created, _ := Field(record{}, "Created")
+	name, options := created.TagName("json")
+	assert.Equal(t, "created", name)
+	assert.Equal(t, []string{"omitempty"}, options)
+
+	value, ok := created.Lookup("json")
+	assert.True(t, ok)
+	assert.Equal(t, "created,omitempty", value)
+
+	recordName, _ := Field(record{}, "Name")
+	name, options = recordName.TagName("json")
+	assert.Equal(t, "name", name)
+	assert.Nil(t, options)
+
+	name, options = recordName.TagName("xml")
+	assert.Equal(t, "", name)
+	assert.Nil(t, options)
+}
@@ -0,0 +1,233 @@
+package reflectutil
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// Returned when an argument cannot be converted to the type of a parameter
+var ErrArgument = errors.New("invalid argument")
+
+// Identifies a method looked up by name on a type
+type methodKey struct {
+	typ  reflect.Type
+	name string
+}
+
+var methods sync.Map
+
+// Returns the index of the exported method with the name in the method set of
+// the type, caching the lookup, or false when there is no such method
+func methodIndex(t reflect.Type, name string) (int, bool) {
+	key := methodKey{typ: t, name: name}
+	if cached, ok := methods.Load(key); ok {
+		index := cached.(int)
+		return index, index >= 0
+	}
+
+	index := -1
+	if method, ok := t.MethodByName(name); ok {
+		index = method.Index
+	}
+	methods.Store(key, index)
+	return index, index >= 0
+}
+
+// Calls the exported method with the name, returning its results.  Methods
+// with pointer receivers are only found when obj is a pointer.  Each argument
+// is converted to the type of its parameter:
+//
+//   - values assignable to the parameter are passed as they are
+//   - nil is passed as the zero value of pointers, interfaces, maps, slices,
+//     channels and functions
+//   - numbers are converted to other numeric types when the value fits, and
+//     floats only when they are whole numbers for integer parameters
+//   - strings are parsed for numeric and bool parameters
+//   - values are converted to named types with the same underlying kind
+//
+// Returns an error wrapping ErrNotFound when there is no such method or
+// ErrArgument when the arguments do not match.  Errors returned by the
+// method are among the results and are not returned as the error.  Method
+// lookups are cached per type and name.
+func InvokeMethod(obj any, name string, args ...any) ([]any, error) {
+	if obj == nil {
+		return nil, fmt.Errorf("%w: method %s of nil", ErrNotFound, name)
+	}
+	receiver := reflect.ValueOf(obj)
+	index, ok := methodIndex(receiver.Type(), name)
+	if !ok {
+		return nil, fmt.Errorf("%w: method %s of %T", ErrNotFound, name, obj)
+	}
+	method := receiver.Method(index)
+
+	signature := method.Type()
+	fixed := signature.NumIn()
+	if signature.IsVariadic() {
+		fixed--
+		if len(args) < fixed {
+			return nil, fmt.Errorf("%w: %s expects at least %d arguments but was given %d", ErrArgument, name, fixed, len(args))
+		}
+	} else if len(args) != fixed {
+		return nil, fmt.Errorf("%w: %s expects %d arguments but was given %d", ErrArgument, name, fixed, len(args))
+	}
+
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		var parameter reflect.Type
+		if i < fixed {
+			parameter = signature.In(i)
+		} else {
+			parameter = signature.In(fixed).Elem()
+		}
+		converted, err := convert(arg, parameter)
+		if err != nil {
+			return nil, fmt.Errorf("%w: argument %d of %s", err, i+1, name)
+		}
+		in[i] = converted
+	}
+
+	out := method.Call(in)
+	results := make([]any, len(out))
+	for i, result := range out {
+		results[i] = result.Interface()
+	}
+	return results, nil
+}
+
+// Converts the value to the type as described by InvokeMethod
+func convert(value any, target reflect.Type) (reflect.Value, error) {
+	failed := fmt.Errorf("%w: cannot convert %T to %s", ErrArgument, value, target)
+	if value == nil {
+		switch target.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+			return reflect.Zero(target), nil
+		}
+		return reflect.Value{}, failed
+	}
+
+	source := reflect.ValueOf(value)
+	if source.Type().AssignableTo(target) {
+		return source, nil
+	}
+	result := reflect.New(target).Elem()
+
+	switch {
+	case source.Kind() == reflect.String && target.Kind() != reflect.String:
+		if !parseInto(result, source.String()) {
+			return reflect.Value{}, failed
+		}
+		return result, nil
+	case isNumber(source.Kind()) && isNumber(target.Kind()):
+		if !convertNumber(result, source) {
+			return reflect.Value{}, failed
+		}
+		return result, nil
+	case source.Kind() == target.Kind() && source.Type().ConvertibleTo(target):
+		return source.Convert(target), nil
+	}
+	return reflect.Value{}, failed
+}
+
+func isNumber(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// Stores the number in the result when it fits without losing its value
+func convertNumber(result reflect.Value, source reflect.Value) bool {
+	switch {
+	case result.CanInt():
+		switch {
+		case source.CanInt():
+			if result.OverflowInt(source.Int()) {
+				return false
+			}
+			result.SetInt(source.Int())
+		case source.CanUint():
+			if source.Uint() > math.MaxInt64 || result.OverflowInt(int64(source.Uint())) {
+				return false
+			}
+			result.SetInt(int64(source.Uint()))
+		default:
+			f := source.Float()
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 || result.OverflowInt(int64(f)) {
+				return false
+			}
+			result.SetInt(int64(f))
+		}
+	case result.CanUint():
+		switch {
+		case source.CanInt():
+			if source.Int() < 0 || result.OverflowUint(uint64(source.Int())) {
+				return false
+			}
+			result.SetUint(uint64(source.Int()))
+		case source.CanUint():
+			if result.OverflowUint(source.Uint()) {
+				return false
+			}
+			result.SetUint(source.Uint())
+		default:
+			f := source.Float()
+			if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 || result.OverflowUint(uint64(f)) {
+				return false
+			}
+			result.SetUint(uint64(f))
+		}
+	default:
+		switch {
+		case source.CanInt():
+			result.SetFloat(float64(source.Int()))
+		case source.CanUint():
+			result.SetFloat(float64(source.Uint()))
+		default:
+			if result.OverflowFloat(source.Float()) {
+				return false
+			}
+			result.SetFloat(source.Float())
+		}
+	}
+	return true
+}
+
+// Parses the text into a numeric or bool result
+func parseInto(result reflect.Value, text string) bool {
+	switch {
+	case result.Kind() == reflect.Bool:
+		parsed, err := strconv.ParseBool(text)
+		if err != nil {
+			return false
+		}
+		result.SetBool(parsed)
+	case result.CanInt():
+		parsed, err := strconv.ParseInt(text, 10, result.Type().Bits())
+		if err != nil {
+			return false
+		}
+		result.SetInt(parsed)
+	case result.CanUint():
+		parsed, err := strconv.ParseUint(text, 10, result.Type().Bits())
+		if err != nil {
+			return false
+		}
+		result.SetUint(parsed)
+	case result.CanFloat():
+		parsed, err := strconv.ParseFloat(text, result.Type().Bits())
+		if err != nil {
+			return false
+		}
+		result.SetFloat(parsed)
+	default:
+		return false
+	}
+	return true
+}
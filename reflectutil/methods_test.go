@@ -0,0 +1,124 @@
+package reflectutil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type calculator struct {
+	total float64
+}
+
+func (c calculator) Add(a int, b int64) int64 {
+	return int64(a) + b
+}
+
+func (c *calculator) Accumulate(values ...float64) float64 {
+	for _, value := range values {
+		c.total += value
+	}
+	return c.total
+}
+
+func (c calculator) Describe(prefix string, level level) (string, error) {
+	if prefix == "" {
+		return "", errors.New("no prefix")
+	}
+	return fmt.Sprintf("%s %d", prefix, level), nil
+}
+
+func (c calculator) Join(separator string, values []string) string {
+	return strings.Join(values, separator)
+}
+
+func (c calculator) Wait(timeout time.Duration, enabled bool, stringer fmt.Stringer) string {
+	return fmt.Sprint(timeout, enabled, stringer == nil)
+}
+
+func TestInvokeMethod(t *testing.T) {
+	var tests = map[string]struct {
+		obj      any
+		name     string
+		args     []any
+		expected []any
+	}{
+		"exact types":         {obj: calculator{}, name: "Add", args: []any{1, int64(2)}, expected: []any{int64(3)}},
+		"converted numbers":   {obj: calculator{}, name: "Add", args: []any{int8(1), uint(2)}, expected: []any{int64(3)}},
+		"whole float":         {obj: calculator{}, name: "Add", args: []any{2.0, 3}, expected: []any{int64(5)}},
+		"parsed strings":      {obj: calculator{}, name: "Add", args: []any{"4", "5"}, expected: []any{int64(9)}},
+		"named type":          {obj: calculator{}, name: "Describe", args: []any{"level", 3}, expected: []any{"level 3", nil}},
+		"error result":        {obj: calculator{}, name: "Describe", args: []any{"", 3}, expected: []any{"", errors.New("no prefix")}},
+		"nil slice":           {obj: calculator{}, name: "Join", args: []any{",", nil}, expected: []any{""}},
+		"slice":               {obj: calculator{}, name: "Join", args: []any{"-", []string{"a", "b"}}, expected: []any{"a-b"}},
+		"duration and bool":   {obj: calculator{}, name: "Wait", args: []any{int64(time.Second), "true", nil}, expected: []any{"1s true true"}},
+		"value method by ptr": {obj: &calculator{}, name: "Add", args: []any{1, 1}, expected: []any{int64(2)}},
+		"variadic":            {obj: &calculator{}, name: "Accumulate", args: []any{1, 2.5, float32(0.5)}, expected: []any{4.0}},
+		"variadic empty":      {obj: &calculator{total: 2}, name: "Accumulate", expected: []any{2.0}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := InvokeMethod(test.obj, test.name, test.args...)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestInvokeMethodModifiesPointer(t *testing.T) {
+	c := &calculator{}
+	_, err := InvokeMethod(c, "Accumulate", 1, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 3.0, c.total)
+}
+
+func TestInvokeMethodCachesPerType(t *testing.T) {
+	for i := 0; i < 2; i++ {
+		results, err := InvokeMethod(&calculator{}, "Accumulate", 2)
+		assert.Nil(t, err)
+		assert.Equal(t, []any{2.0}, results)
+
+		_, err = InvokeMethod(calculator{}, "Accumulate", 2)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		results, err = InvokeMethod(&calculator{}, "Add", 1, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, []any{int64(3)}, results)
+	}
+}
+
+func TestInvokeMethodErrors(t *testing.T) {
+	var tests = map[string]struct {
+		obj             any
+		name            string
+		args            []any
+		expectedMessage string
+		expectedKind    error
+	}{
+		"unknown":               {obj: calculator{}, name: "Subtract", expectedMessage: "not found: method Subtract of reflectutil.calculator", expectedKind: ErrNotFound},
+		"pointer receiver":      {obj: calculator{}, name: "Accumulate", expectedMessage: "not found: method Accumulate of reflectutil.calculator", expectedKind: ErrNotFound},
+		"nil":                   {obj: nil, name: "Add", expectedMessage: "not found: method Add of nil", expectedKind: ErrNotFound},
+		"too few":               {obj: calculator{}, name: "Add", args: []any{1}, expectedMessage: "invalid argument: Add expects 2 arguments but was given 1", expectedKind: ErrArgument},
+		"too many":              {obj: calculator{}, name: "Add", args: []any{1, 2, 3}, expectedMessage: "invalid argument: Add expects 2 arguments but was given 3", expectedKind: ErrArgument},
+		"fractional float":      {obj: calculator{}, name: "Add", args: []any{1.5, 2}, expectedMessage: "invalid argument: cannot convert float64 to int: argument 1 of Add", expectedKind: ErrArgument},
+		"overflow":              {obj: calculator{}, name: "Add", args: []any{uint64(1 << 63), 2}, expectedMessage: "invalid argument: cannot convert uint64 to int: argument 1 of Add", expectedKind: ErrArgument},
+		"unparsable":            {obj: calculator{}, name: "Add", args: []any{1, "two"}, expectedMessage: "invalid argument: cannot convert string to int64: argument 2 of Add", expectedKind: ErrArgument},
+		"nil for number":        {obj: calculator{}, name: "Add", args: []any{nil, 1}, expectedMessage: "invalid argument: cannot convert <nil> to int: argument 1 of Add", expectedKind: ErrArgument},
+		"wrong variadic":        {obj: &calculator{}, name: "Accumulate", args: []any{1, "x"}, expectedMessage: "invalid argument: cannot convert string to float64: argument 2 of Accumulate", expectedKind: ErrArgument},
+		"number for a string":   {obj: calculator{}, name: "Describe", args: []any{1, 1}, expectedMessage: "invalid argument: cannot convert int to string: argument 1 of Describe", expectedKind: ErrArgument},
+		"wrong interface value": {obj: calculator{}, name: "Wait", args: []any{1, true, 1}, expectedMessage: "invalid argument: cannot convert int to fmt.Stringer: argument 3 of Wait", expectedKind: ErrArgument},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := InvokeMethod(test.obj, test.name, test.args...)
+			assert.EqualError(t, err, test.expectedMessage)
+			assert.ErrorIs(t, err, test.expectedKind)
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package reflectutil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Returned when a field path cannot be parsed
+	ErrInvalidPath = errors.New("invalid field path")
+	// Returned when a value cannot be set, such as a field reached through a
+	// map value or an object that is not passed as a pointer
+	ErrNotSettable = errors.New("value cannot be set")
+)
+
+// A field name, or an index or key in brackets, of a field path
+type step struct {
+	name    string
+	key     string
+	isIndex bool
+}
+
+func (s step) String() string {
+	if s.isIndex {
+		return "[" + s.key + "]"
+	}
+	return s.name
+}
+
+// Splits a path such as "a.b[0].c" or "labels[team]" into steps
+func parsePath(path string) ([]step, error) {
+	invalid := fmt.Errorf("%w: %q", ErrInvalidPath, path)
+	var steps []step
+	for rest := path; ; {
+		end := strings.IndexAny(rest, ".[")
+		if end < 0 {
+			end = len(rest)
+		}
+		if end == 0 {
+			return nil, invalid
+		}
+		steps = append(steps, step{name: rest[:end]})
+		rest = rest[end:]
+
+		for strings.HasPrefix(rest, "[") {
+			closing := strings.IndexByte(rest, ']')
+			if closing < 0 {
+				return nil, invalid
+			}
+			steps = append(steps, step{key: rest[1:closing], isIndex: true})
+			rest = rest[closing+1:]
+		}
+
+		if rest == "" {
+			return steps, nil
+		}
+		if !strings.HasPrefix(rest, ".") {
+			return nil, invalid
+		}
+		rest = rest[1:]
+	}
+}
+
+// Returns the value at the path, dereferencing pointers and interfaces along
+// the way.  Paths are made of exported field names separated by dots, with
+// slice and array indexes or map keys in brackets:
+//
+//	GetField(config, "Server.Listeners[0].Port")
+//	GetField(config, "Labels[team]")
+//
+// Returns an error wrapping ErrNotFound when a field or key does not exist or
+// a nil pointer is reached, ErrInvalidPath when the path cannot be parsed or
+// an index is out of range, and ErrInvalidType when a step does not apply to
+// the value it is applied to.
+func GetField(obj any, path string) (any, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	value := reflect.ValueOf(obj)
+	for i, s := range steps {
+		if value, err = walk(value, s, steps[:i]); err != nil {
+			return nil, err
+		}
+	}
+	return value.Interface(), nil
+}
+
+// Sets the value at the path, converting the value to the type of the field
+// as InvokeMethod converts arguments.  The object must be a pointer so that
+// its fields can be set.  Map entries can be set, including new keys, but
+// fields of struct values stored in maps cannot.  Returns the errors of
+// GetField, or an error wrapping ErrNotSettable or ErrInvalidType when the
+// value cannot be set or converted.
+func SetField(obj any, path string, newValue any) error {
+	steps, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	value := reflect.ValueOf(obj)
+	if value.Kind() != reflect.Pointer {
+		return fmt.Errorf("%w: %T is not a pointer", ErrNotSettable, obj)
+	}
+	if value.IsNil() {
+		return fmt.Errorf("%w: %T is nil", ErrNotSettable, obj)
+	}
+
+	last := len(steps) - 1
+	for i, s := range steps[:last] {
+		if value, err = walk(value, s, steps[:i]); err != nil {
+			return err
+		}
+	}
+
+	container, err := indirect(value, steps[:last])
+	if err != nil {
+		return err
+	}
+	if steps[last].isIndex && container.Kind() == reflect.Map {
+		if container.IsNil() {
+			return fmt.Errorf("%w: the map %s is nil", ErrNotSettable, joinSteps(steps[:last]))
+		}
+		key, err := mapKey(container, steps[last], steps[:last])
+		if err != nil {
+			return err
+		}
+		converted, err := convert(newValue, container.Type().Elem())
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, path)
+		}
+		container.SetMapIndex(key, converted)
+		return nil
+	}
+
+	target, err := walk(value, steps[last], steps[:last])
+	if err != nil {
+		return err
+	}
+	if !target.CanSet() {
+		return fmt.Errorf("%w: %s", ErrNotSettable, path)
+	}
+	converted, err := convert(newValue, target.Type())
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, path)
+	}
+	target.Set(converted)
+	return nil
+}
+
+// Dereferences pointers and interfaces, failing when one is nil
+func indirect(value reflect.Value, walked []step) (reflect.Value, error) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return value, fmt.Errorf("%w: %s is nil", ErrNotFound, describe(walked))
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return value, fmt.Errorf("%w: %s is nil", ErrNotFound, describe(walked))
+	}
+	return value, nil
+}
+
+// Applies one step of a path to the value
+func walk(value reflect.Value, s step, walked []step) (reflect.Value, error) {
+	value, err := indirect(value, walked)
+	if err != nil {
+		return value, err
+	}
+	path := joinSteps(append(walked[:len(walked):len(walked)], s))
+
+	if !s.isIndex {
+		if value.Kind() != reflect.Struct {
+			return value, fmt.Errorf("%w: %s is not a struct at %s", ErrInvalidType, value.Type(), path)
+		}
+		field, ok := infoOf(value.Type()).byName[s.name]
+		if !ok {
+			return value, fmt.Errorf("%w: field %s of %s at %s", ErrNotFound, s.name, value.Type(), path)
+		}
+		result, err := value.FieldByIndexErr(field.Index)
+		if err != nil {
+			return value, fmt.Errorf("%w: %s is reached through a nil embedded pointer", ErrNotFound, path)
+		}
+		return result, nil
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		index, err := strconv.Atoi(s.key)
+		if err != nil || index < 0 || index >= value.Len() {
+			return value, fmt.Errorf("%w: index %s out of range at %s", ErrInvalidPath, s.key, path)
+		}
+		return value.Index(index), nil
+	case reflect.Map:
+		key, err := mapKey(value, s, walked)
+		if err != nil {
+			return value, err
+		}
+		result := value.MapIndex(key)
+		if !result.IsValid() {
+			return value, fmt.Errorf("%w: key %s at %s", ErrNotFound, s.key, path)
+		}
+		return result, nil
+	}
+	return value, fmt.Errorf("%w: %s cannot be indexed at %s", ErrInvalidType, value.Type(), path)
+}
+
+// Converts the text of a key in brackets to the key type of the map
+func mapKey(m reflect.Value, s step, walked []step) (reflect.Value, error) {
+	key, err := convert(s.key, m.Type().Key())
+	if err != nil {
+		return key, fmt.Errorf("%w: at %s", err, joinSteps(append(walked[:len(walked):len(walked)], s)))
+	}
+	return key, nil
+}
+
+func joinSteps(steps []step) string {
+	var builder strings.Builder
+	for i, s := range steps {
+		if i > 0 && !s.isIndex {
+			builder.WriteByte('.')
+		}
+		builder.WriteString(s.String())
+	}
+	return builder.String()
+}
+
+func describe(walked []step) string {
+	if len(walked) == 0 {
+		return "the object"
+	}
+	return joinSteps(walked)
+}
@@ -0,0 +1,169 @@
+package reflectutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type listener struct {
+	Port  int
+	Hosts []string
+}
+
+type level int
+
+type server struct {
+	Name      string
+	Listeners []listener
+	Primary   *listener
+	Labels    map[string]string
+	Limits    map[int]level
+	Backends  map[string]listener
+	Extra     any
+	Ports     [2]uint16
+	Level     level
+}
+
+func newServer() *server {
+	return &server{
+		Name:      "api",
+		Listeners: []listener{{Port: 80, Hosts: []string{"a", "b"}}, {Port: 443}},
+		Primary:   &listener{Port: 8080},
+		Labels:    map[string]string{"team": "core"},
+		Limits:    map[int]level{1: 10},
+		Backends:  map[string]listener{"db": {Port: 5432}},
+		Extra:     &listener{Port: 9090},
+		Ports:     [2]uint16{1, 2},
+	}
+}
+
+func TestGetField(t *testing.T) {
+	var tests = map[string]struct {
+		obj      any
+		path     string
+		expected any
+	}{
+		"field":             {path: "Name", expected: "api"},
+		"slice element":     {path: "Listeners[1]", expected: listener{Port: 443}},
+		"nested":            {path: "Listeners[0].Port", expected: 80},
+		"nested index":      {path: "Listeners[0].Hosts[1]", expected: "b"},
+		"pointer":           {path: "Primary.Port", expected: 8080},
+		"map":               {path: "Labels[team]", expected: "core"},
+		"integer map key":   {path: "Limits[1]", expected: level(10)},
+		"struct in map":     {path: "Backends[db].Port", expected: 5432},
+		"through interface": {path: "Extra.Port", expected: 9090},
+		"array":             {path: "Ports[1]", expected: uint16(2)},
+		"whole slice":       {path: "Listeners[0].Hosts", expected: []string{"a", "b"}},
+		"promoted":          {obj: record{audit: &audit{Editor: "ed"}}, path: "Editor", expected: "ed"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj := test.obj
+			if obj == nil {
+				obj = newServer()
+			}
+			actual, err := GetField(obj, test.path)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestGetFieldErrors(t *testing.T) {
+	var tests = map[string]struct {
+		obj             any
+		path            string
+		expectedMessage string
+		expectedKind    error
+	}{
+		"empty path":         {obj: newServer(), path: "", expectedMessage: `invalid field path: ""`, expectedKind: ErrInvalidPath},
+		"empty name":         {obj: newServer(), path: "Primary..Port", expectedMessage: `invalid field path: "Primary..Port"`, expectedKind: ErrInvalidPath},
+		"unclosed bracket":   {obj: newServer(), path: "Labels[team", expectedMessage: `invalid field path: "Labels[team"`, expectedKind: ErrInvalidPath},
+		"text after bracket": {obj: newServer(), path: "Listeners[0]Port", expectedMessage: `invalid field path: "Listeners[0]Port"`, expectedKind: ErrInvalidPath},
+		"leading bracket":    {obj: newServer(), path: "[0]", expectedMessage: `invalid field path: "[0]"`, expectedKind: ErrInvalidPath},
+		"unknown field":      {obj: newServer(), path: "Primary.Host", expectedMessage: "not found: field Host of reflectutil.listener at Primary.Host", expectedKind: ErrNotFound},
+		"out of range":       {obj: newServer(), path: "Listeners[2].Port", expectedMessage: "invalid field path: index 2 out of range at Listeners[2]", expectedKind: ErrInvalidPath},
+		"bad index":          {obj: newServer(), path: "Listeners[x]", expectedMessage: "invalid field path: index x out of range at Listeners[x]", expectedKind: ErrInvalidPath},
+		"missing key":        {obj: newServer(), path: "Labels[owner]", expectedMessage: "not found: key owner at Labels[owner]", expectedKind: ErrNotFound},
+		"bad key":            {obj: newServer(), path: "Limits[one]", expectedMessage: "invalid argument: cannot convert string to int: at Limits[one]", expectedKind: ErrArgument},
+		"not a struct":       {obj: newServer(), path: "Name.Length", expectedMessage: "invalid type: string is not a struct at Name.Length", expectedKind: ErrInvalidType},
+		"not indexable":      {obj: newServer(), path: "Name[0]", expectedMessage: "invalid type: string cannot be indexed at Name[0]", expectedKind: ErrInvalidType},
+		"nil pointer":        {obj: &server{}, path: "Primary.Port", expectedMessage: "not found: Primary is nil", expectedKind: ErrNotFound},
+		"nil object":         {obj: nil, path: "Name", expectedMessage: "not found: the object is nil", expectedKind: ErrNotFound},
+		"nil embedded":       {obj: record{}, path: "Editor", expectedMessage: "not found: Editor is reached through a nil embedded pointer", expectedKind: ErrNotFound},
+		"unexported":         {obj: record{}, path: "hidden", expectedMessage: "not found: field hidden of reflectutil.record at hidden", expectedKind: ErrNotFound},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := GetField(test.obj, test.path)
+			assert.EqualError(t, err, test.expectedMessage)
+			assert.ErrorIs(t, err, test.expectedKind)
+		})
+	}
+}
+
+func TestSetField(t *testing.T) {
+	s := newServer()
+
+	assert.Nil(t, SetField(s, "Name", "web"))
+	assert.Nil(t, SetField(s, "Listeners[0].Port", int64(8000)))
+	assert.Nil(t, SetField(s, "Listeners[0].Hosts[0]", "z"))
+	assert.Nil(t, SetField(s, "Primary.Port", "8081"))
+	assert.Nil(t, SetField(s, "Labels[team]", "web"))
+	assert.Nil(t, SetField(s, "Labels[owner]", "ann"))
+	assert.Nil(t, SetField(s, "Limits[2]", 20))
+	assert.Nil(t, SetField(s, "Ports[0]", 65535))
+	assert.Nil(t, SetField(s, "Level", 3))
+	assert.Nil(t, SetField(s, "Extra.Port", 1))
+	assert.Nil(t, SetField(s, "Primary", nil))
+
+	assert.Equal(t, "web", s.Name)
+	assert.Equal(t, 8000, s.Listeners[0].Port)
+	assert.Equal(t, "z", s.Listeners[0].Hosts[0])
+	assert.Equal(t, map[string]string{"team": "web", "owner": "ann"}, s.Labels)
+	assert.Equal(t, map[int]level{1: 10, 2: 20}, s.Limits)
+	assert.Equal(t, [2]uint16{65535, 2}, s.Ports)
+	assert.Equal(t, level(3), s.Level)
+	assert.Equal(t, 1, s.Extra.(*listener).Port)
+	assert.Nil(t, s.Primary)
+
+	r := &record{audit: &audit{}}
+	assert.Nil(t, SetField(r, "Editor", "ed"))
+	assert.Nil(t, SetField(r, "Base.ID", 7))
+	assert.Equal(t, "ed", r.Editor)
+	assert.Equal(t, 7, r.Base.ID)
+}
+
+func TestSetFieldErrors(t *testing.T) {
+	var tests = map[string]struct {
+		obj             any
+		path            string
+		value           any
+		expectedMessage string
+		expectedKind    error
+	}{
+		"not a pointer":    {obj: *newServer(), path: "Name", value: "x", expectedMessage: "value cannot be set: reflectutil.server is not a pointer", expectedKind: ErrNotSettable},
+		"nil pointer":      {obj: (*server)(nil), path: "Name", value: "x", expectedMessage: "value cannot be set: *reflectutil.server is nil", expectedKind: ErrNotSettable},
+		"struct in map":    {obj: newServer(), path: "Backends[db].Port", value: 1, expectedMessage: "value cannot be set: Backends[db].Port", expectedKind: ErrNotSettable},
+		"nil map":          {obj: &server{}, path: "Labels[team]", value: "x", expectedMessage: "value cannot be set: the map Labels is nil", expectedKind: ErrNotSettable},
+		"wrong type":       {obj: newServer(), path: "Name", value: 1, expectedMessage: "invalid argument: cannot convert int to string: Name", expectedKind: ErrArgument},
+		"overflow":         {obj: newServer(), path: "Ports[0]", value: 65536, expectedMessage: "invalid argument: cannot convert int to uint16: Ports[0]", expectedKind: ErrArgument},
+		"wrong map value":  {obj: newServer(), path: "Labels[team]", value: 1, expectedMessage: "invalid argument: cannot convert int to string: Labels[team]", expectedKind: ErrArgument},
+		"wrong map key":    {obj: newServer(), path: "Limits[x]", value: 1, expectedMessage: "invalid argument: cannot convert string to int: at Limits[x]", expectedKind: ErrArgument},
+		"nil for a number": {obj: newServer(), path: "Level", value: nil, expectedMessage: "invalid argument: cannot convert <nil> to reflectutil.level: Level", expectedKind: ErrArgument},
+		"unknown field":    {obj: newServer(), path: "Missing", value: 1, expectedMessage: "not found: field Missing of reflectutil.server at Missing", expectedKind: ErrNotFound},
+		"through nil":      {obj: &server{}, path: "Primary.Port", value: 1, expectedMessage: "not found: Primary is nil", expectedKind: ErrNotFound},
+		"invalid path":     {obj: newServer(), path: "Name.", value: 1, expectedMessage: `invalid field path: "Name."`, expectedKind: ErrInvalidPath},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := SetField(test.obj, test.path, test.value)
+			assert.EqualError(t, err, test.expectedMessage)
+			assert.ErrorIs(t, err, test.expectedKind)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package retry
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Returns the delay before the next attempt.  The attempt is the number of
+// attempts made so far, starting at 1, and previous is the delay returned for
+// the previous attempt or zero after the first.  A Backoff must be safe for
+// use by multiple goroutines.
+type Backoff func(attempt int, previous time.Duration) time.Duration
+
+// Waits the same delay before every attempt
+func Constant(delay time.Duration) Backoff {
+	return func(int, time.Duration) time.Duration {
+		return delay
+	}
+}
+
+// Waits initial before the second attempt and multiplies the delay by the
+// multiplier for each attempt after that, never waiting longer than max
+func Exponential(initial time.Duration, multiplier float64, max time.Duration) Backoff {
+	return func(attempt int, _ time.Duration) time.Duration {
+		delay := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+		if delay >= float64(max) {
+			return max
+		}
+		return time.Duration(delay)
+	}
+}
+
+// Waits a random delay between base and three times the previous delay,
+// never waiting longer than max.  This is the "decorrelated jitter" strategy,
+// which spreads out the retries of many clients better than exponential
+// backoff.  The random function returns a number in [0, 1), when nil the
+// math/rand package is used.
+func DecorrelatedJitter(base time.Duration, max time.Duration, random func() float64) Backoff {
+	if random == nil {
+		random = rand.Float64
+	}
+	return func(_ int, previous time.Duration) time.Duration {
+		if previous < base {
+			previous = base
+		}
+		upper := 3 * float64(previous)
+		if upper > float64(max) {
+			upper = float64(max)
+		}
+		delay := float64(base) + random()*(upper-float64(base))
+		if delay >= float64(max) {
+			return max
+		}
+		return time.Duration(delay)
+	}
+}
+
+// Waits unit multiplied by the Fibonacci numbers 1, 1, 2, 3, 5, 8 and so on,
+// never waiting longer than max
+func Fibonacci(unit time.Duration, max time.Duration) Backoff {
+	return func(attempt int, _ time.Duration) time.Duration {
+		a, b := time.Duration(0), unit
+		for i := 1; i < attempt; i++ {
+			if b > max-a {
+				return max
+			}
+			a, b = b, a+b
+		}
+		if b > max {
+			return max
+		}
+		return b
+	}
+}
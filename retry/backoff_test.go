@@ -0,0 +1,82 @@
+package retry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func delays(backoff Backoff, attempts int) []time.Duration {
+	var result []time.Duration
+	var previous time.Duration
+	for attempt := 1; attempt <= attempts; attempt++ {
+		previous = backoff(attempt, previous)
+		result = append(result, previous)
+	}
+	return result
+}
+
+func TestBackoff(t *testing.T) {
+	ms := time.Millisecond
+
+	var tests = map[string]struct {
+		backoff  Backoff
+		expected []time.Duration
+	}{
+		"constant": {
+			backoff:  Constant(50 * ms),
+			expected: []time.Duration{50 * ms, 50 * ms, 50 * ms},
+		},
+		"exponential": {
+			backoff:  Exponential(100*ms, 2, time.Second),
+			expected: []time.Duration{100 * ms, 200 * ms, 400 * ms, 800 * ms, time.Second, time.Second},
+		},
+		"exponential with fractional multiplier": {
+			backoff:  Exponential(100*ms, 1.5, time.Second),
+			expected: []time.Duration{100 * ms, 150 * ms, 225 * ms},
+		},
+		"exponential does not overflow": {
+			backoff:  Exponential(time.Hour, 10, 24*time.Hour),
+			expected: []time.Duration{time.Hour, 10 * time.Hour, 24 * time.Hour},
+		},
+		"fibonacci": {
+			backoff:  Fibonacci(10*ms, 100*ms),
+			expected: []time.Duration{10 * ms, 10 * ms, 20 * ms, 30 * ms, 50 * ms, 80 * ms, 100 * ms, 100 * ms},
+		},
+		"decorrelated jitter at the lower bound": {
+			backoff:  DecorrelatedJitter(100*ms, time.Second, func() float64 { return 0 }),
+			expected: []time.Duration{100 * ms, 100 * ms, 100 * ms},
+		},
+		"decorrelated jitter at the upper bound": {
+			backoff:  DecorrelatedJitter(100*ms, time.Second, func() float64 { return 1 }),
+			expected: []time.Duration{300 * ms, 900 * ms, time.Second, time.Second},
+		},
+		"decorrelated jitter halfway": {
+			backoff:  DecorrelatedJitter(100*ms, time.Second, func() float64 { return 0.5 }),
+			expected: []time.Duration{200 * ms, 350 * ms, 550 * ms, 550 * ms},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, delays(test.backoff, len(test.expected)))
+		})
+	}
+}
+
+func TestFibonacciDoesNotOverflow(t *testing.T) {
+	max := time.Duration(1<<63 - 1)
+	assert.Equal(t, max, Fibonacci(time.Hour, max)(200, 0))
+}
+
+func TestDecorrelatedJitterStaysInRange(t *testing.T) {
+	backoff := DecorrelatedJitter(10*time.Millisecond, time.Second, nil)
+	var previous time.Duration
+	for attempt := 1; attempt <= 100; attempt++ {
+		delay := backoff(attempt, previous)
+		assert.GreaterOrEqual(t, delay, 10*time.Millisecond)
+		assert.LessOrEqual(t, delay, time.Second)
+		previous = delay
+	}
+}
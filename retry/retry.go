@@ -0,0 +1,221 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jwmajors81/golang-commons-lang/timeutil"
+)
+
+var (
+	// The reason given up when the maximum number of attempts were made
+	ErrMaxAttempts = errors.New("maximum attempts reached")
+	// The reason given up when waiting for another attempt would exceed the maximum elapsed time
+	ErrMaxElapsed = errors.New("maximum elapsed time reached")
+)
+
+// Describes how to retry a function.  The zero Policy retries immediately
+// and without limit until the function succeeds, returns an error that is
+// not retryable or the context is done.
+type Policy struct {
+	// The delay between attempts, no delay when nil
+	Backoff Backoff
+	// The maximum number of attempts including the first, no limit when zero
+	MaxAttempts int
+	// The maximum time from the start of the first attempt until the start
+	// of the last, no limit when zero
+	MaxElapsed time.Duration
+	// The timeout of the context passed to each attempt, no timeout when zero
+	AttemptTimeout time.Duration
+	// Returns whether an error should be retried, every error is retried when nil
+	Retryable func(err error) bool
+	// Called after every attempt, useful for logging
+	OnAttempt func(attempt Attempt)
+	// The clock used to wait between attempts, the system clock when nil
+	Clock timeutil.Clock
+}
+
+// Returns a policy of three attempts with exponential backoff starting at
+// 100 milliseconds
+func DefaultPolicy() Policy {
+	return Policy{
+		Backoff:     Exponential(100*time.Millisecond, 2, 10*time.Second),
+		MaxAttempts: 3,
+	}
+}
+
+// Describes a completed attempt to the OnAttempt hook
+type Attempt struct {
+	// The number of the attempt, starting at 1
+	Number int
+	// The error returned by the attempt, nil when it succeeded
+	Err error
+	// The delay before the next attempt, zero when there will not be one
+	Delay time.Duration
+	// The time since the first attempt started
+	Elapsed time.Duration
+}
+
+// Returned by Do when it gives up on a retryable error.  It matches the
+// reason with errors.Is and unwraps to the error of the last attempt.
+type Error struct {
+	// The number of attempts made
+	Attempts int
+	// Why no further attempts were made: ErrMaxAttempts, ErrMaxElapsed or the
+	// error of the context
+	Reason error
+	// The error returned by the last attempt
+	Last error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("giving up after %d attempts, %v: %v", e.Attempts, e.Reason, e.Last)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Last
+}
+
+func (e *Error) Is(target error) bool {
+	return errors.Is(e.Reason, target)
+}
+
+type permanent struct {
+	err error
+}
+
+func (p permanent) Error() string {
+	return p.err.Error()
+}
+
+func (p permanent) Unwrap() error {
+	return p.err
+}
+
+// Marks an error as not retryable, whatever the policy says.  Do returns the
+// error that was wrapped.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanent{err: err}
+}
+
+// Returns a predicate for Policy.Retryable that retries errors matching any
+// of the targets with errors.Is
+func RetryIf(targets ...error) func(err error) bool {
+	return func(err error) bool {
+		for _, target := range targets {
+			if errors.Is(err, target) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Returns a predicate for Policy.Retryable that retries errors with an error
+// of type T in their chain, as found by errors.As
+func RetryIfType[T error]() func(err error) bool {
+	return func(err error) bool {
+		var target T
+		return errors.As(err, &target)
+	}
+}
+
+// Returns a predicate for Policy.Retryable that retries errors matching
+// none of the targets with errors.Is
+func RetryUnless(targets ...error) func(err error) bool {
+	matches := RetryIf(targets...)
+	return func(err error) bool {
+		return !matches(err)
+	}
+}
+
+// Calls fn until it succeeds or the policy gives up.  Errors that are not
+// retryable are returned as is.  When the policy gives up on a retryable
+// error, or the context is done while waiting, an *Error is returned.
+func Do(ctx context.Context, fn func(ctx context.Context) error, policy Policy) error {
+	_, err := DoValue(ctx, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	}, policy)
+	return err
+}
+
+// Calls fn until it succeeds or the policy gives up, returning its value
+func DoValue[T any](ctx context.Context, fn func(ctx context.Context) (T, error), policy Policy) (T, error) {
+	clock := policy.Clock
+	if clock == nil {
+		clock = timeutil.SystemClock
+	}
+	start := clock.Now()
+
+	var zero T
+	var delay time.Duration
+	for attempt := 1; ; attempt++ {
+		value, err := call(ctx, fn, policy.AttemptTimeout)
+		elapsed := clock.Now().Sub(start)
+		if err == nil {
+			policy.notify(Attempt{Number: attempt, Elapsed: elapsed})
+			return value, nil
+		}
+
+		var p permanent
+		if errors.As(err, &p) {
+			policy.notify(Attempt{Number: attempt, Err: p.err, Elapsed: elapsed})
+			return zero, p.err
+		}
+		if policy.Retryable != nil && !policy.Retryable(err) {
+			policy.notify(Attempt{Number: attempt, Err: err, Elapsed: elapsed})
+			return zero, err
+		}
+		if ctx.Err() != nil {
+			policy.notify(Attempt{Number: attempt, Err: err, Elapsed: elapsed})
+			return zero, &Error{Attempts: attempt, Reason: ctx.Err(), Last: err}
+		}
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			policy.notify(Attempt{Number: attempt, Err: err, Elapsed: elapsed})
+			return zero, &Error{Attempts: attempt, Reason: ErrMaxAttempts, Last: err}
+		}
+
+		delay = policy.delay(attempt, delay)
+		if policy.MaxElapsed > 0 && elapsed+delay > policy.MaxElapsed {
+			policy.notify(Attempt{Number: attempt, Err: err, Elapsed: elapsed})
+			return zero, &Error{Attempts: attempt, Reason: ErrMaxElapsed, Last: err}
+		}
+		policy.notify(Attempt{Number: attempt, Err: err, Delay: delay, Elapsed: elapsed})
+
+		if delay > 0 {
+			select {
+			case <-ctx.Done():
+				return zero, &Error{Attempts: attempt, Reason: ctx.Err(), Last: err}
+			case <-clock.After(delay):
+			}
+		}
+	}
+}
+
+// Makes one attempt, with a timeout when one is given
+func call[T any](ctx context.Context, fn func(ctx context.Context) (T, error), timeout time.Duration) (T, error) {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return fn(ctx)
+}
+
+func (p Policy) delay(attempt int, previous time.Duration) time.Duration {
+	if p.Backoff == nil {
+		return 0
+	}
+	return p.Backoff(attempt, previous)
+}
+
+func (p Policy) notify(attempt Attempt) {
+	if p.OnAttempt != nil {
+		p.OnAttempt(attempt)
+	}
+}
@@ -0,0 +1,259 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jwmajors81/golang-commons-lang/timeutil"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errTemporary = errors.New("temporary failure")
+	errFatal     = errors.New("fatal failure")
+)
+
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("status %d", e.code)
+}
+
+// A fake clock that moves forward as soon as it is waited on
+type instantClock struct {
+	*timeutil.FakeClock
+}
+
+func (c instantClock) After(d time.Duration) <-chan time.Time {
+	c.Advance(d)
+	return c.FakeClock.After(0)
+}
+
+func newInstantClock() instantClock {
+	return instantClock{timeutil.NewFakeClock(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))}
+}
+
+// Returns a function that fails with the errors provided, one per call, and then succeeds
+func failingWith(calls *int, errs ...error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		*calls++
+		if *calls <= len(errs) {
+			return errs[*calls-1]
+		}
+		return nil
+	}
+}
+
+func TestDo(t *testing.T) {
+	var tests = map[string]struct {
+		policy           Policy
+		errs             []error
+		expectedCalls    int
+		expectedError    error
+		expectedReason   error
+		expectedDuration time.Duration
+	}{
+		"succeeds first time": {
+			policy:        Policy{MaxAttempts: 3},
+			expectedCalls: 1,
+		},
+		"succeeds after retries": {
+			policy:           Policy{MaxAttempts: 3, Backoff: Constant(time.Second)},
+			errs:             []error{errTemporary, errTemporary},
+			expectedCalls:    3,
+			expectedDuration: 2 * time.Second,
+		},
+		"gives up after max attempts": {
+			policy:           Policy{MaxAttempts: 3, Backoff: Exponential(time.Second, 2, time.Minute)},
+			errs:             []error{errTemporary, errTemporary, errTemporary, errTemporary},
+			expectedCalls:    3,
+			expectedError:    errTemporary,
+			expectedReason:   ErrMaxAttempts,
+			expectedDuration: 3 * time.Second,
+		},
+		"gives up before exceeding max elapsed": {
+			policy:           Policy{MaxElapsed: 10 * time.Second, Backoff: Fibonacci(time.Second, time.Minute)},
+			errs:             []error{errTemporary, errTemporary, errTemporary, errTemporary, errTemporary, errTemporary},
+			expectedCalls:    5,
+			expectedError:    errTemporary,
+			expectedReason:   ErrMaxElapsed,
+			expectedDuration: 7 * time.Second,
+		},
+		"not retryable": {
+			policy:        Policy{MaxAttempts: 3, Retryable: RetryIf(errTemporary)},
+			errs:          []error{errFatal},
+			expectedCalls: 1,
+			expectedError: errFatal,
+		},
+		"retryable by type": {
+			policy:        Policy{MaxAttempts: 3, Retryable: RetryIfType[*statusError]()},
+			errs:          []error{fmt.Errorf("calling service: %w", &statusError{code: 503}), errFatal},
+			expectedCalls: 2,
+			expectedError: errFatal,
+		},
+		"retry unless": {
+			policy:        Policy{Retryable: RetryUnless(errFatal)},
+			errs:          []error{errTemporary, errTemporary, fmt.Errorf("wrapped: %w", errFatal)},
+			expectedCalls: 3,
+			expectedError: errFatal,
+		},
+		"permanent": {
+			policy:        Policy{MaxAttempts: 3},
+			errs:          []error{errTemporary, Permanent(errFatal)},
+			expectedCalls: 2,
+			expectedError: errFatal,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			clock := newInstantClock()
+			start := clock.Now()
+			test.policy.Clock = clock
+
+			calls := 0
+			err := Do(context.Background(), failingWith(&calls, test.errs...), test.policy)
+
+			assert.Equal(t, test.expectedCalls, calls)
+			assert.Equal(t, test.expectedDuration, clock.Now().Sub(start))
+			if test.expectedError == nil {
+				assert.Nil(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, test.expectedError)
+
+			var retryErr *Error
+			if test.expectedReason == nil {
+				assert.False(t, errors.As(err, &retryErr))
+			} else {
+				assert.ErrorIs(t, err, test.expectedReason)
+				assert.True(t, errors.As(err, &retryErr))
+				assert.Equal(t, test.expectedCalls, retryErr.Attempts)
+			}
+		})
+	}
+}
+
+func TestDoErrorMessage(t *testing.T) {
+	calls := 0
+	err := Do(context.Background(), failingWith(&calls, errTemporary, errTemporary), Policy{MaxAttempts: 2})
+	assert.EqualError(t, err, "giving up after 2 attempts, maximum attempts reached: temporary failure")
+}
+
+func TestDoValue(t *testing.T) {
+	calls := 0
+	value, err := DoValue(context.Background(), func(ctx context.Context) (string, error) {
+		calls++
+		if calls < 2 {
+			return "", errTemporary
+		}
+		return "done", nil
+	}, Policy{MaxAttempts: 3, Clock: newInstantClock()})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "done", value)
+
+	value, err = DoValue(context.Background(), func(ctx context.Context) (string, error) {
+		return "partial", errFatal
+	}, Policy{MaxAttempts: 1})
+	assert.ErrorIs(t, err, errFatal)
+	assert.Equal(t, "", value)
+}
+
+func TestDoOnAttempt(t *testing.T) {
+	var attempts []Attempt
+	policy := Policy{
+		MaxAttempts: 3,
+		Backoff:     Exponential(time.Second, 2, time.Minute),
+		Clock:       newInstantClock(),
+		OnAttempt: func(attempt Attempt) {
+			attempts = append(attempts, attempt)
+		},
+	}
+
+	calls := 0
+	err := Do(context.Background(), failingWith(&calls, errTemporary, errTemporary, errTemporary), policy)
+	assert.ErrorIs(t, err, ErrMaxAttempts)
+	assert.Equal(t, []Attempt{
+		{Number: 1, Err: errTemporary, Delay: time.Second},
+		{Number: 2, Err: errTemporary, Delay: 2 * time.Second, Elapsed: time.Second},
+		{Number: 3, Err: errTemporary, Elapsed: 3 * time.Second},
+	}, attempts)
+
+	attempts = nil
+	calls = 0
+	assert.Nil(t, Do(context.Background(), failingWith(&calls, errTemporary), policy))
+	assert.Equal(t, []Attempt{
+		{Number: 1, Err: errTemporary, Delay: time.Second},
+		{Number: 2, Elapsed: time.Second},
+	}, attempts)
+}
+
+func TestDoCancelledWhileWaiting(t *testing.T) {
+	clock := timeutil.NewFakeClock(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error)
+	go func() {
+		calls := 0
+		done <- Do(ctx, failingWith(&calls, errTemporary, errTemporary), Policy{Backoff: Constant(time.Minute), Clock: clock})
+	}()
+
+	clock.BlockUntil(1)
+	cancel()
+	err := <-done
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, errTemporary)
+}
+
+func TestDoAttemptTimeout(t *testing.T) {
+	calls := 0
+	err := Do(context.Background(), func(ctx context.Context) error {
+		calls++
+		if calls == 1 {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+		return nil
+	}, Policy{MaxAttempts: 2, AttemptTimeout: time.Millisecond})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestDoWithDoneContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	err := Do(ctx, func(ctx context.Context) error {
+		calls++
+		return ctx.Err()
+	}, Policy{})
+	assert.Equal(t, 1, calls)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestDefaultPolicy(t *testing.T) {
+	clock := newInstantClock()
+	start := clock.Now()
+	policy := DefaultPolicy()
+	policy.Clock = clock
+
+	calls := 0
+	err := Do(context.Background(), failingWith(&calls, errTemporary, errTemporary, errTemporary), policy)
+	assert.ErrorIs(t, err, ErrMaxAttempts)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 300*time.Millisecond, clock.Now().Sub(start))
+}
+
+func TestPermanentNil(t *testing.T) {
+	assert.Nil(t, Permanent(nil))
+}
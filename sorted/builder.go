@@ -0,0 +1,117 @@
+package sorted
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Builds a comparison one field at a time, similar to Apache Commons
+// CompareToBuilder.  The first field that differs determines the result and
+// all further comparisons are skipped.
+//
+//	result := NewCompareToBuilder().
+//		Append(a.LastName, b.LastName).
+//		Append(a.FirstName, b.FirstName).
+//		Result()
+type CompareToBuilder struct {
+	result int
+}
+
+// Creates a builder whose result starts out as equal
+func NewCompareToBuilder() *CompareToBuilder {
+	return &CompareToBuilder{}
+}
+
+// Compares two values of the same ordered type, such as ints, floats,
+// strings, bools (false before true) or time.Time.  Nil sorts before every
+// other value, including nil pointers, maps and slices, and two nils are
+// equal.  Values of different types are ordered by the names of their types,
+// so int sorts before string.  Append panics when both values have a type
+// that has no natural ordering; use AppendWith for those values.
+func (b *CompareToBuilder) Append(left, right any) *CompareToBuilder {
+	if b.result != 0 {
+		return b
+	}
+	b.result = compareAny(left, right)
+	return b
+}
+
+// Records the result of a comparison that was performed elsewhere, such as
+// the comparison of an embedded type
+func (b *CompareToBuilder) AppendResult(result int) *CompareToBuilder {
+	if b.result != 0 {
+		return b
+	}
+	b.result = result
+	return b
+}
+
+// Compares two values using the comparator provided
+func AppendWith[T any](b *CompareToBuilder, left, right T, cmp Comparator[T]) *CompareToBuilder {
+	if b.result != 0 {
+		return b
+	}
+	b.result = cmp(left, right)
+	return b
+}
+
+// Returns a negative number, zero or a positive number depending on whether
+// the left values sort before, equal to or after the right values
+func (b *CompareToBuilder) Result() int {
+	return b.result
+}
+
+func compareAny(left, right any) int {
+	if l, ok := left.(time.Time); ok {
+		if r, ok := right.(time.Time); ok {
+			switch {
+			case l.Before(r):
+				return -1
+			case l.After(r):
+				return 1
+			}
+			return 0
+		}
+	}
+
+	l, r := reflect.ValueOf(left), reflect.ValueOf(right)
+	if leftNil, rightNil := isNil(l), isNil(r); leftNil || rightNil {
+		return Compare(boolToInt(!leftNil), boolToInt(!rightNil))
+	}
+	if l.Type() != r.Type() {
+		return Compare(fmt.Sprintf("%T", left), fmt.Sprintf("%T", right))
+	}
+
+	switch l.Kind() {
+	case reflect.Bool:
+		return Compare(boolToInt(l.Bool()), boolToInt(r.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Compare(l.Int(), r.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Compare(l.Uint(), r.Uint())
+	case reflect.Float32, reflect.Float64:
+		return Compare(l.Float(), r.Float())
+	case reflect.String:
+		return Compare(l.String(), r.String())
+	}
+
+	panic(fmt.Sprintf("values of type %T have no natural ordering", left))
+}
+
+func isNil(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface, reflect.Chan, reflect.Func:
+		return value.IsNil()
+	}
+	return false
+}
+
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
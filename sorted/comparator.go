@@ -0,0 +1,153 @@
+package sorted
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Compares two values returning a negative number when a sorts before b,
+// zero when they are equivalent and a positive number when a sorts after b
+type Comparator[T any] func(a, b T) int
+
+// Compares two ordered values.  NaN sorts before all other floating point values.
+func Compare[T constraints.Ordered](a, b T) int {
+	aNaN, bNaN := a != a, b != b
+	switch {
+	case aNaN && bNaN:
+		return 0
+	case aNaN:
+		return -1
+	case bNaN:
+		return 1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Returns a comparator using the natural ordering of the type
+func NaturalOrder[T constraints.Ordered]() Comparator[T] {
+	return Compare[T]
+}
+
+// Returns a comparator that orders values by the key extracted from them
+func Comparing[T any, K constraints.Ordered](key func(T) K) Comparator[T] {
+	return func(a, b T) int {
+		return Compare(key(a), key(b))
+	}
+}
+
+// Returns a comparator that orders values by the key extracted from them
+// using the comparator provided for the key
+func ComparingWith[T any, K any](key func(T) K, cmp Comparator[K]) Comparator[T] {
+	return func(a, b T) int {
+		return cmp(key(a), key(b))
+	}
+}
+
+// Returns a comparator that uses the next comparator to break ties
+func (c Comparator[T]) ThenComparing(next Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if result := c(a, b); result != 0 {
+			return result
+		}
+		return next(a, b)
+	}
+}
+
+// Returns a comparator that imposes the reverse ordering
+func (c Comparator[T]) Reversed() Comparator[T] {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// Sorts the values in place.  The sort is stable.
+func (c Comparator[T]) Sort(values []T) {
+	sort.SliceStable(values, func(i, j int) bool {
+		return c(values[i], values[j]) < 0
+	})
+}
+
+// Returns the smallest of the values, the zero value is returned when no values are provided
+func (c Comparator[T]) Min(values ...T) T {
+	var min T
+	for i, val := range values {
+		if i == 0 || c(val, min) < 0 {
+			min = val
+		}
+	}
+	return min
+}
+
+// Returns the largest of the values, the zero value is returned when no values are provided
+func (c Comparator[T]) Max(values ...T) T {
+	var max T
+	for i, val := range values {
+		if i == 0 || c(val, max) > 0 {
+			max = val
+		}
+	}
+	return max
+}
+
+// Returns a comparator for pointers that sorts nil before all other values
+func NilsFirst[T any](c Comparator[T]) Comparator[*T] {
+	return func(a, b *T) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		case b == nil:
+			return 1
+		}
+		return c(*a, *b)
+	}
+}
+
+// Returns a comparator for pointers that sorts nil after all other values
+func NilsLast[T any](c Comparator[T]) Comparator[*T] {
+	return func(a, b *T) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return 1
+		case b == nil:
+			return -1
+		}
+		return c(*a, *b)
+	}
+}
+
+// Returns a comparator that orders strings by Unicode code point.  Unlike
+// locale aware collation the result does not depend on the environment.
+func CodePointOrder() Comparator[string] {
+	return Compare[string]
+}
+
+// Returns a comparator that orders strings ignoring case.  Strings that differ
+// only by case are ordered by code point so the ordering is total and
+// independent of the locale.
+func CaseInsensitive() Comparator[string] {
+	return Comparator[string](compareFold).ThenComparing(CodePointOrder())
+}
+
+// Compares strings rune by rune after folding each rune to lower case
+func compareFold(a, b string) int {
+	for len(a) > 0 && len(b) > 0 {
+		ra, sizeA := utf8.DecodeRuneInString(a)
+		rb, sizeB := utf8.DecodeRuneInString(b)
+		if result := Compare(unicode.ToLower(ra), unicode.ToLower(rb)); result != 0 {
+			return result
+		}
+		a, b = a[sizeA:], b[sizeB:]
+	}
+	return Compare(len(a), len(b))
+}
@@ -0,0 +1,165 @@
+package sorted
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Employee struct {
+	LastName  string
+	FirstName string
+	Age       int
+}
+
+func sign(value int) int {
+	return Compare(value, 0)
+}
+
+func TestCompare(t *testing.T) {
+	assert.Equal(t, -1, Compare(1, 2))
+	assert.Equal(t, 1, Compare(2, 1))
+	assert.Equal(t, 0, Compare(2, 2))
+	assert.Equal(t, -1, Compare("a", "b"))
+	assert.Equal(t, -1, Compare(math.NaN(), 1))
+	assert.Equal(t, 1, Compare(1, math.NaN()))
+	assert.Equal(t, 0, Compare(math.NaN(), math.NaN()))
+}
+
+func TestComparingThenComparing(t *testing.T) {
+	employees := []Employee{
+		{LastName: "smith", FirstName: "sam", Age: 40},
+		{LastName: "jones", FirstName: "mary", Age: 30},
+		{LastName: "smith", FirstName: "anne", Age: 25},
+		{LastName: "jones", FirstName: "mary", Age: 20},
+	}
+
+	byName := Comparing(func(e Employee) string { return e.LastName }).
+		ThenComparing(Comparing(func(e Employee) string { return e.FirstName })).
+		ThenComparing(Comparing(func(e Employee) int { return e.Age }).Reversed())
+	byName.Sort(employees)
+
+	assert.Equal(t, []Employee{
+		{LastName: "jones", FirstName: "mary", Age: 30},
+		{LastName: "jones", FirstName: "mary", Age: 20},
+		{LastName: "smith", FirstName: "anne", Age: 25},
+		{LastName: "smith", FirstName: "sam", Age: 40},
+	}, employees)
+}
+
+func TestComparingWith(t *testing.T) {
+	byLastName := ComparingWith(func(e Employee) string { return e.LastName }, CaseInsensitive())
+	assert.Equal(t, -1, sign(byLastName(Employee{LastName: "Jones"}, Employee{LastName: "smith"})))
+}
+
+func TestReversed(t *testing.T) {
+	values := []int{3, 1, 2}
+	NaturalOrder[int]().Reversed().Sort(values)
+	assert.Equal(t, []int{3, 2, 1}, values)
+}
+
+func TestComparatorMinMax(t *testing.T) {
+	byAge := Comparing(func(e Employee) int { return e.Age })
+	employees := []Employee{{FirstName: "a", Age: 3}, {FirstName: "b", Age: 1}, {FirstName: "c", Age: 2}}
+
+	assert.Equal(t, "b", byAge.Min(employees...).FirstName)
+	assert.Equal(t, "a", byAge.Max(employees...).FirstName)
+	assert.Equal(t, Employee{}, byAge.Min())
+}
+
+func TestNilsFirstAndLast(t *testing.T) {
+	one, two := 1, 2
+	values := []*int{&two, nil, &one}
+
+	NilsFirst(NaturalOrder[int]()).Sort(values)
+	assert.Equal(t, []*int{nil, &one, &two}, values)
+
+	NilsLast(NaturalOrder[int]()).Sort(values)
+	assert.Equal(t, []*int{&one, &two, nil}, values)
+
+	assert.Equal(t, 0, NilsFirst(NaturalOrder[int]())(nil, nil))
+}
+
+func TestCaseInsensitive(t *testing.T) {
+	var tests = map[string]struct {
+		a, b     string
+		expected int
+	}{
+		"equal":             {a: "abc", b: "abc", expected: 0},
+		"differ by case":    {a: "abc", b: "ABD", expected: -1},
+		"upper sorts first": {a: "ABC", b: "abc", expected: -1},
+		"prefix":            {a: "ab", b: "ABC", expected: -1},
+		"unicode":           {a: "ÉCOLE", b: "école", expected: -1},
+		"unicode order":     {a: "éa", b: "ÉB", expected: -1},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, sign(CaseInsensitive()(test.a, test.b)))
+			assert.Equal(t, -test.expected, sign(CaseInsensitive()(test.b, test.a)))
+		})
+	}
+
+	values := []string{"b", "C", "a", "B"}
+	CaseInsensitive().Sort(values)
+	assert.Equal(t, []string{"a", "B", "b", "C"}, values)
+}
+
+func TestNatural(t *testing.T) {
+	values := []string{"file10", "file2", "file1", "file02", "file", "file10a"}
+	Natural().Sort(values)
+	assert.Equal(t, []string{"file", "file1", "file2", "file02", "file10", "file10a"}, values)
+}
+
+func TestCompareToBuilder(t *testing.T) {
+	a := Employee{LastName: "smith", FirstName: "sam", Age: 40}
+	b := Employee{LastName: "smith", FirstName: "anne", Age: 25}
+
+	assert.Equal(t, 0, NewCompareToBuilder().Result())
+	assert.Equal(t, 0, NewCompareToBuilder().Append(a.LastName, b.LastName).Result())
+	assert.Equal(t, 1, NewCompareToBuilder().Append(a.LastName, b.LastName).Append(a.FirstName, b.FirstName).Result())
+	assert.Equal(t, 1, NewCompareToBuilder().Append(a.Age, b.Age).Append(a.FirstName, b.FirstName).Result())
+	assert.Equal(t, -1, NewCompareToBuilder().AppendResult(-1).Append(a.Age, b.Age).Result())
+	assert.Equal(t, -1, NewCompareToBuilder().Append(false, true).Result())
+	assert.Equal(t, -1, NewCompareToBuilder().Append(uint8(1), uint8(2)).Result())
+	assert.Equal(t, 1, NewCompareToBuilder().Append(2.5, 1.5).Result())
+
+	now := time.Now()
+	assert.Equal(t, -1, NewCompareToBuilder().Append(now, now.Add(time.Second)).Result())
+
+	builder := AppendWith(NewCompareToBuilder(), "Smith", "smith", CaseInsensitive())
+	assert.Equal(t, -1, sign(builder.Result()))
+
+	assert.Panics(t, func() { NewCompareToBuilder().Append([]int{1}, []int{2}) })
+}
+
+func TestCompareToBuilderNilsAndMixedTypes(t *testing.T) {
+	var noName *string
+	name := "bob"
+
+	var tests = map[string]struct {
+		left, right any
+		expected    int
+	}{
+		"nils":                   {left: nil, right: nil, expected: 0},
+		"nil first":              {left: nil, right: 1, expected: -1},
+		"nil last":               {left: "a", right: nil, expected: 1},
+		"nil pointers":           {left: noName, right: (*int)(nil), expected: 0},
+		"nil pointer first":      {left: noName, right: &name, expected: -1},
+		"nil slice and nil":      {left: []int(nil), right: nil, expected: 0},
+		"nil before empty slice": {left: []int(nil), right: []int{}, expected: -1},
+		"types by name":          {left: 1, right: "a", expected: -1},
+		"types by name reversed": {left: "a", right: 1, expected: 1},
+		"sized integers":         {left: int64(1), right: int32(2), expected: 1},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				assert.Equal(t, test.expected, sign(NewCompareToBuilder().Append(test.left, test.right).Result()))
+			})
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package sorted
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// NaturalOption configures how strings are compared in natural order
+type NaturalOption func(*naturalConfig)
+
+type naturalConfig struct {
+	ignoreCase       bool
+	decimalFractions bool
+}
+
+// Compares the text between numbers without regard to case
+func IgnoreCase() NaturalOption {
+	return func(c *naturalConfig) {
+		c.ignoreCase = true
+	}
+}
+
+// Treats digits following a decimal point as a fraction so that "1.5" sorts
+// after "1.25".  By default every run of digits is an integer, which orders
+// version numbers such as "1.10" after "1.9".
+func DecimalFractions() NaturalOption {
+	return func(c *naturalConfig) {
+		c.decimalFractions = true
+	}
+}
+
+// Returns a comparator that orders strings the way a person would, treating
+// runs of digits as numbers so that "file2" sorts before "file10".  Any
+// Unicode decimal digit is recognised and sorts against other characters as
+// '0' does.  The ordering is total: strings that
+// are equal apart from leading zeros, case when ignored, or the script of
+// their digits are ordered by the first such difference, placing fewer
+// leading zeros, upper case and lower code points first.  The comparator
+// returns 0 only for identical strings.
+func Natural(opts ...NaturalOption) Comparator[string] {
+	c := &naturalConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c.compare
+}
+
+// Compares two strings in natural order
+func NaturalCompare(a, b string, opts ...NaturalOption) int {
+	return Natural(opts...)(a, b)
+}
+
+// Sorts the strings in natural order
+func NaturalSort(values []string, opts ...NaturalOption) {
+	Natural(opts...).Sort(values)
+}
+
+func (c *naturalConfig) compare(a, b string) int {
+	// the first difference that does not affect the ordering on its own, such
+	// as leading zeros or case, decides between otherwise equal strings
+	tieBreak := 0
+	// digits of different scripts with the same value are equal until the
+	// strings themselves are compared
+	originalA, originalB := a, b
+
+	for len(a) > 0 && len(b) > 0 {
+		ra, sizeA := utf8.DecodeRuneInString(a)
+		rb, sizeB := utf8.DecodeRuneInString(b)
+
+		if unicode.IsDigit(ra) && unicode.IsDigit(rb) {
+			var digitsA, digitsB []int
+			digitsA, a = digitRun(a)
+			digitsB, b = digitRun(b)
+			result, tie := compareInteger(digitsA, digitsB)
+			if result != 0 {
+				return result
+			}
+			if tieBreak == 0 {
+				tieBreak = tie
+			}
+
+			if c.decimalFractions && startsFraction(a) && startsFraction(b) {
+				digitsA, a = digitRun(a[1:])
+				digitsB, b = digitRun(b[1:])
+				result, tie := compareFraction(digitsA, digitsB)
+				if result != 0 {
+					return result
+				}
+				if tieBreak == 0 {
+					tieBreak = tie
+				}
+			}
+			continue
+		}
+
+		// a digit sorts against other characters as '0' does, whatever its
+		// script, so that every number is placed consistently among the text
+		if unicode.IsDigit(ra) {
+			ra = '0'
+		} else if unicode.IsDigit(rb) {
+			rb = '0'
+		}
+		if c.ignoreCase {
+			if result := Compare(unicode.ToLower(ra), unicode.ToLower(rb)); result != 0 {
+				return result
+			}
+		}
+		if result := Compare(ra, rb); result != 0 {
+			if !c.ignoreCase {
+				return result
+			}
+			if tieBreak == 0 {
+				tieBreak = result
+			}
+		}
+		a, b = a[sizeA:], b[sizeB:]
+	}
+
+	if result := Compare(len(a), len(b)); result != 0 {
+		return result
+	}
+	if tieBreak == 0 {
+		tieBreak = Compare(originalA, originalB)
+	}
+	return tieBreak
+}
+
+// Returns whether the string starts with a decimal point followed by a digit
+func startsFraction(value string) bool {
+	if len(value) < 2 || value[0] != '.' {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(value[1:])
+	return unicode.IsDigit(r)
+}
+
+// Splits the leading digits off the string and returns their values
+func digitRun(value string) ([]int, string) {
+	var digits []int
+	for len(value) > 0 {
+		r, size := utf8.DecodeRuneInString(value)
+		if !unicode.IsDigit(r) {
+			break
+		}
+		digits = append(digits, digitValue(r))
+		value = value[size:]
+	}
+	return digits, value
+}
+
+// Returns the value of a Unicode decimal digit.  Decimal digits are encoded
+// in contiguous runs from zero to nine, so the value is the offset from the
+// start of the run.
+func digitValue(r rune) int {
+	if r >= '0' && r <= '9' {
+		return int(r - '0')
+	}
+	for _, rng := range unicode.Nd.R16 {
+		if r >= rune(rng.Lo) && r <= rune(rng.Hi) {
+			return int(r-rune(rng.Lo)) % 10
+		}
+	}
+	for _, rng := range unicode.Nd.R32 {
+		if r >= rune(rng.Lo) && r <= rune(rng.Hi) {
+			return int(r-rune(rng.Lo)) % 10
+		}
+	}
+	return 0
+}
+
+// Compares two integers given as digits without converting them, so
+// arbitrarily long numbers cannot overflow.  The second result breaks ties
+// between numbers that differ only by leading zeros.
+func compareInteger(a, b []int) (int, int) {
+	trimmedA, trimmedB := trimLeadingZeros(a), trimLeadingZeros(b)
+	if result := Compare(len(trimmedA), len(trimmedB)); result != 0 {
+		return result, 0
+	}
+	for i := range trimmedA {
+		if result := Compare(trimmedA[i], trimmedB[i]); result != 0 {
+			return result, 0
+		}
+	}
+	return 0, Compare(len(a), len(b))
+}
+
+// Compares the digits following a decimal point.  The second result breaks
+// ties between fractions that differ only by trailing zeros.
+func compareFraction(a, b []int) (int, int) {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		digitA, digitB := 0, 0
+		if i < len(a) {
+			digitA = a[i]
+		}
+		if i < len(b) {
+			digitB = b[i]
+		}
+		if result := Compare(digitA, digitB); result != 0 {
+			return result, 0
+		}
+	}
+	return 0, Compare(len(a), len(b))
+}
+
+func trimLeadingZeros(digits []int) []int {
+	for len(digits) > 1 && digits[0] == 0 {
+		digits = digits[1:]
+	}
+	return digits
+}
@@ -0,0 +1,167 @@
+package sorted
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNaturalCompare(t *testing.T) {
+	var tests = map[string]struct {
+		a, b     string
+		opts     []NaturalOption
+		expected int
+	}{
+		"empty strings":                {a: "", b: "", expected: 0},
+		"empty before text":            {a: "", b: "a", expected: -1},
+		"equal text":                   {a: "abc", b: "abc", expected: 0},
+		"plain text":                   {a: "abc", b: "abd", expected: -1},
+		"prefix first":                 {a: "file", b: "file1", expected: -1},
+		"single digits":                {a: "file2", b: "file3", expected: -1},
+		"digit run length":             {a: "file2", b: "file10", expected: -1},
+		"versions":                     {a: "v2", b: "v10", expected: -1},
+		"equal numbers":                {a: "file10", b: "file10", expected: 0},
+		"number then text":             {a: "file10a", b: "file10b", expected: -1},
+		"number before suffix":         {a: "file10", b: "file10a", expected: -1},
+		"multiple numbers":             {a: "1.2.10", b: "1.2.9", expected: 1},
+		"version segments":             {a: "v1.10", b: "v1.9", expected: 1},
+		"leading zeros equal value":    {a: "file02", b: "file2", expected: 1},
+		"leading zeros bigger value":   {a: "file002", b: "file10", expected: -1},
+		"leading zeros decide last":    {a: "a02b", b: "a2c", expected: -1},
+		"all zeros":                    {a: "0", b: "000", expected: -1},
+		"digits before letters":        {a: "1", b: "a", expected: -1},
+		"huge numbers":                 {a: "id99999999999999999999998", b: "id99999999999999999999999", expected: -1},
+		"huge number against small":    {a: "id100000000000000000000000", b: "id9", expected: 1},
+		"case sensitive":               {a: "B", b: "a", expected: -1},
+		"case insensitive":             {a: "B", b: "a", opts: []NaturalOption{IgnoreCase()}, expected: 1},
+		"case insensitive tie":         {a: "A1", b: "a1", opts: []NaturalOption{IgnoreCase()}, expected: -1},
+		"case insensitive tie is last": {a: "Ab", b: "aC", opts: []NaturalOption{IgnoreCase()}, expected: -1},
+		"case insensitive numbers":     {a: "FILE2", b: "file10", opts: []NaturalOption{IgnoreCase()}, expected: -1},
+		"arabic-indic digits":          {a: "file٢", b: "file١٠", expected: -1},
+		"fullwidth digits":             {a: "ｖ９", b: "ｖ１０", expected: -1},
+		"mixed scripts same value":     {a: "x٣", b: "x3", expected: 1},
+		"mixed scripts decide last":    {a: "x٣b", b: "x3c", expected: -1},
+		"devanagari digits":            {a: "१२", b: "९", expected: 1},
+		"integers by default":          {a: "1.5", b: "1.25", expected: -1},
+		"decimal fractions":            {a: "1.5", b: "1.25", opts: []NaturalOption{DecimalFractions()}, expected: 1},
+		"decimal fractions equal":      {a: "1.50", b: "1.5", opts: []NaturalOption{DecimalFractions()}, expected: 1},
+		"decimal fractions small":      {a: "0.05", b: "0.5", opts: []NaturalOption{DecimalFractions()}, expected: -1},
+		"decimal fractions integer":    {a: "2.1", b: "10.01", opts: []NaturalOption{DecimalFractions()}, expected: -1},
+		"decimal fractions with text":  {a: "price 1.99 usd", b: "price 1.099 usd", opts: []NaturalOption{DecimalFractions()}, expected: 1},
+		"decimal point without digits": {a: "1.a", b: "1.b", opts: []NaturalOption{DecimalFractions()}, expected: -1},
+		"decimal fractions after text": {a: "a.5", b: "a.25", opts: []NaturalOption{DecimalFractions()}, expected: -1},
+		"unicode text":                 {a: "école2", b: "école10", expected: -1},
+		"spaces":                       {a: "chapter 9", b: "chapter 10", expected: -1},
+		"number at start":              {a: "10 apples", b: "9 apples", expected: 1},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, sign(Natural(test.opts...)(test.a, test.b)))
+			assert.Equal(t, -test.expected, sign(Natural(test.opts...)(test.b, test.a)))
+		})
+	}
+}
+
+func TestNaturalCompareDefault(t *testing.T) {
+	assert.Equal(t, -1, NaturalCompare("file2", "file10"))
+	assert.Equal(t, 0, NaturalCompare("file2", "file2"))
+	assert.Equal(t, -1, NaturalCompare("File2", "file2", IgnoreCase()))
+}
+
+func TestNaturalSort(t *testing.T) {
+	var tests = map[string]struct {
+		expected []string
+		opts     []NaturalOption
+	}{
+		"files": {
+			expected: []string{"file", "file1", "file2", "file02", "file10", "file10a", "file10b", "file100"},
+		},
+		"versions": {
+			expected: []string{"v0.9", "v1", "v1.2", "v1.9", "v1.10", "v1.10.1", "v2", "v10"},
+		},
+		"mixed case": {
+			expected: []string{"Alpha2", "alpha10", "BETA1", "beta2", "Gamma"},
+			opts:     []NaturalOption{IgnoreCase()},
+		},
+		"decimals": {
+			expected: []string{"0.05", "0.5", "1", "1.25", "1.5", "1.50", "10.01"},
+			opts:     []NaturalOption{DecimalFractions()},
+		},
+		"images": {
+			expected: []string{"img1.png", "img2.png", "img10.png", "img12.png", "IMG13.png"},
+			opts:     []NaturalOption{IgnoreCase()},
+		},
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 10; i++ {
+				values := make([]string, len(test.expected))
+				copy(values, test.expected)
+				random.Shuffle(len(values), func(i, j int) {
+					values[i], values[j] = values[j], values[i]
+				})
+
+				NaturalSort(values, test.opts...)
+				assert.Equal(t, test.expected, values)
+			}
+		})
+	}
+}
+
+func TestNaturalIsTransitive(t *testing.T) {
+	alphabet := []string{"0", "1", "9", "٣", "０", "a", "A", "b", ".", " ", "é"}
+	options := map[string][]NaturalOption{
+		"default":           nil,
+		"ignore case":       {IgnoreCase()},
+		"decimal fractions": {DecimalFractions()},
+		"both":              {IgnoreCase(), DecimalFractions()},
+	}
+
+	random := rand.New(rand.NewSource(1))
+	randomString := func() string {
+		var builder strings.Builder
+		for i := random.Intn(6); i > 0; i-- {
+			builder.WriteString(alphabet[random.Intn(len(alphabet))])
+		}
+		return builder.String()
+	}
+
+	for name, opts := range options {
+		t.Run(name, func(t *testing.T) {
+			compare := Natural(opts...)
+			for i := 0; i < 20000; i++ {
+				a, b, c := randomString(), randomString(), randomString()
+				ab, bc, ac := sign(compare(a, b)), sign(compare(b, c)), sign(compare(a, c))
+
+				if !assert.Equal(t, -ab, sign(compare(b, a)), "%q %q", a, b) ||
+					!assert.Equal(t, ab == 0, a == b, "%q %q", a, b) {
+					return
+				}
+				if ab <= 0 && bc <= 0 && !assert.LessOrEqual(t, ac, 0, "%q <= %q <= %q", a, b, c) {
+					return
+				}
+				if ab >= 0 && bc >= 0 && !assert.GreaterOrEqual(t, ac, 0, "%q >= %q >= %q", a, b, c) {
+					return
+				}
+			}
+		})
+	}
+
+	compare := Natural()
+	assert.Equal(t, -1, compare("٣0", "1931"))
+	assert.Equal(t, -1, compare("1931", "a"))
+	assert.Equal(t, -1, compare("٣0", "a"))
+}
+
+func TestDigitValue(t *testing.T) {
+	assert.Equal(t, 7, digitValue('7'))
+	assert.Equal(t, 7, digitValue('٧'))
+	assert.Equal(t, 7, digitValue('７'))
+	assert.Equal(t, 7, digitValue('𝟕'))
+	assert.Equal(t, 7, digitValue('𝟽'))
+}
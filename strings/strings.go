@@ -1,13 +1,14 @@
 package utils
 
 import (
-	"errors"
 	"math"
 	"regexp"
 	"strings"
 	"unicode"
 
+	"github.com/jwmajors81/golang-commons-lang/optional"
 	"github.com/jwmajors81/golang-commons-lang/sorted"
+	"github.com/jwmajors81/golang-commons-lang/validate"
 )
 
 const (
@@ -21,8 +22,8 @@ const (
 func Abbreviate(value string, maxWidth int) (string, error) {
 	if maxWidth >= len(value) {
 		return value, nil
-	} else if maxWidth < 4 {
-		return "", errors.New("the maxWidth must be greater than 3")
+	} else if err := validate.IsTrue(maxWidth >= 4, "the maxWidth must be greater than 3"); err != nil {
+		return "", err
 	} else if (len(value) - 3) > 0 {
 		runes := []rune(value)
 		return string(runes[:maxWidth-3]) + "...", nil
@@ -159,8 +160,8 @@ func Center(original string, size int, char rune) (string, error) {
 
 // Add padding to the left of the original string using the value specified
 func LeftPad(original string, size int, char rune) (string, error) {
-	if !unicode.IsPrint(char) {
-		return "", errors.New("the padding character must be a printable character as defined by unicode.IsPrint")
+	if err := validate.IsTrue(unicode.IsPrint(char), "the padding character must be a printable character as defined by unicode.IsPrint"); err != nil {
+		return "", err
 	}
 
 	charNum := size - len(original)
@@ -174,8 +175,8 @@ func LeftPad(original string, size int, char rune) (string, error) {
 
 // Add padding to the right of the string using the value specified
 func RightPad(original string, size int, char rune) (string, error) {
-	if !unicode.IsPrint(char) {
-		return "", errors.New("the padding character must be a printable character as defined by unicode.IsPrint")
+	if err := validate.IsTrue(unicode.IsPrint(char), "the padding character must be a printable character as defined by unicode.IsPrint"); err != nil {
+		return "", err
 	}
 
 	charNum := size - len(original)
@@ -209,22 +210,15 @@ func ContainsOnly(original string, searchFor ...string) bool {
 	return len(stringToSearch) == 0
 }
 
-// Finds the first non-empty string and returns the value if found, otherwise nil is returned
+// Finds the first non-empty string and returns the value if found, otherwise nil is returned.
+// optional.FirstNonZero provides the same for all comparable types.
 func FirstNonEmpty(values ...string) *string {
-	for _, val := range values {
-		if len(val) > 0 {
-			return &val
-		}
-	}
-
-	return nil
+	return optional.FirstNonZero(values...).Ptr()
 }
 
+// Returns the string the pointer refers to, or an empty string when it is nil
 func SafeDeref(value *string) string {
-	if value == nil {
-		return ""
-	}
-	return *value
+	return optional.Deref(value)
 }
 
 // Compares all strings and returns the initial sequence of chracters that are common
@@ -511,3 +505,15 @@ func Unwrap(original string, wrapVal string) string {
 
 	return original
 }
+
+// Compares two strings the way a person would, treating runs of digits as
+// numbers so that "file2" sorts before "file10".  See sorted.Natural for the
+// options and how ties are broken.
+func NaturalCompare(a string, b string, opts ...sorted.NaturalOption) int {
+	return sorted.NaturalCompare(a, b, opts...)
+}
+
+// Sorts the strings the way a person would, treating runs of digits as numbers
+func NaturalSort(values []string, opts ...sorted.NaturalOption) {
+	sorted.NaturalSort(values, opts...)
+}
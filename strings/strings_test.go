@@ -4,6 +4,8 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/jwmajors81/golang-commons-lang/sorted"
+	"github.com/jwmajors81/golang-commons-lang/validate"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -28,7 +30,8 @@ func TestAbbreviate(t *testing.T) {
 
 			if test.expectedError != nil {
 				assert.Equal(t, "", actual)
-				assert.Equal(t, test.expectedError, err)
+				assert.EqualError(t, err, test.expectedError.Error())
+				assert.ErrorIs(t, err, validate.ErrIllegalArgument)
 			} else {
 				assert.Equal(t, test.expectedOutput, actual)
 			}
@@ -61,7 +64,8 @@ func TestCenter(t *testing.T) {
 				assert.Nil(t, err)
 			} else {
 				assert.Equal(t, val.expectedOutput, actual)
-				assert.Equal(t, val.expectedError, err)
+				assert.EqualError(t, err, val.expectedError.Error())
+				assert.ErrorIs(t, err, validate.ErrIllegalArgument)
 			}
 		})
 	}
@@ -91,7 +95,8 @@ func TestLeftPad(t *testing.T) {
 				assert.Nil(t, err)
 			} else {
 				assert.Equal(t, val.expectedOutput, actual)
-				assert.Equal(t, val.expectedError, err)
+				assert.EqualError(t, err, val.expectedError.Error())
+				assert.ErrorIs(t, err, validate.ErrIllegalArgument)
 			}
 		})
 	}
@@ -121,7 +126,8 @@ func TestRightPad(t *testing.T) {
 				assert.Nil(t, err)
 			} else {
 				assert.Equal(t, val.expectedOutput, actual)
-				assert.Equal(t, val.expectedError, err)
+				assert.EqualError(t, err, val.expectedError.Error())
+				assert.ErrorIs(t, err, validate.ErrIllegalArgument)
 			}
 		})
 	}
@@ -414,3 +420,24 @@ func TestUnwrap(t *testing.T) {
 	assert.Equal(t, "#A", Unwrap("#A", "#"))
 	assert.Equal(t, "A#", Unwrap("A#", "#"))
 }
+
+func TestNaturalCompare(t *testing.T) {
+	assert.Equal(t, -1, NaturalCompare("v2", "v10"))
+	assert.Equal(t, 1, NaturalCompare("v10", "v2"))
+	assert.Equal(t, 0, NaturalCompare("v10", "v10"))
+	assert.Equal(t, -1, NaturalCompare("V2", "v10", sorted.IgnoreCase()))
+	assert.Equal(t, -1, NaturalCompare("V2", "v2"))
+	assert.Equal(t, -1, NaturalCompare("V2", "v2", sorted.IgnoreCase()))
+	assert.Equal(t, 1, NaturalCompare("b2", "A10", sorted.IgnoreCase()))
+	assert.Equal(t, 1, NaturalCompare("b2", "A10"))
+}
+
+func TestNaturalSort(t *testing.T) {
+	values := []string{"v10", "v2", "v1", "v1.10", "v1.9"}
+	NaturalSort(values)
+	assert.Equal(t, []string{"v1", "v1.9", "v1.10", "v2", "v10"}, values)
+
+	values = []string{"File10", "file2", "FILE1"}
+	NaturalSort(values, sorted.IgnoreCase())
+	assert.Equal(t, []string{"FILE1", "file2", "File10"}, values)
+}
@@ -0,0 +1,127 @@
+package timeutil
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and timers so that time dependent code can
+// be tested deterministically
+type Clock interface {
+	Now() time.Time
+	// Returns a channel that receives the current time once the duration has passed
+	After(d time.Duration) <-chan time.Time
+}
+
+// The Clock backed by the system time
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+type waiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// A Clock whose time only changes when it is told to.  Channels returned by
+// After receive a value once the clock has been moved to or past their
+// deadline.  It is safe for use by multiple goroutines.
+type FakeClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []waiter
+}
+
+// Creates a fake clock set to the time provided
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Returns the current time of the fake clock
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Returns a channel that receives the time of the clock once it has been
+// moved forward by at least the duration provided
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, waiter{at: c.now.Add(d), ch: ch})
+	c.signal().Broadcast()
+	return ch
+}
+
+// Moves the clock forward by the duration provided
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.fire()
+}
+
+// Sets the clock to the time provided
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+	c.fire()
+}
+
+// Returns the number of channels returned by After that have not yet received a value
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// Blocks until at least n channels returned by After are waiting for the
+// clock to move, so that a test can advance the clock once the code under
+// test is asleep
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.signal().Wait()
+	}
+}
+
+// Sends the time to every waiter whose deadline has passed.  Must be called
+// with the lock held.
+func (c *FakeClock) fire() {
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			pending = append(pending, w)
+		} else {
+			w.ch <- c.now
+		}
+	}
+	c.waiters = pending
+}
+
+// Returns the condition used by BlockUntil, creating it on first use so that
+// the zero FakeClock is usable.  Must be called with the lock held.
+func (c *FakeClock) signal() *sync.Cond {
+	if c.cond == nil {
+		c.cond = sync.NewCond(&c.mu)
+	}
+	return c.cond
+}
@@ -0,0 +1,60 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClockAfter(t *testing.T) {
+	clock := NewFakeClock(epoch)
+
+	first := clock.After(time.Second)
+	second := clock.After(3 * time.Second)
+	assert.Equal(t, 2, clock.Waiters())
+
+	clock.Advance(500 * time.Millisecond)
+	assert.Len(t, first, 0)
+
+	clock.Advance(500 * time.Millisecond)
+	assert.Equal(t, epoch.Add(time.Second), <-first)
+	assert.Equal(t, 1, clock.Waiters())
+
+	clock.Set(epoch.Add(time.Hour))
+	assert.Equal(t, epoch.Add(time.Hour), <-second)
+	assert.Equal(t, 0, clock.Waiters())
+}
+
+func TestFakeClockAfterWithoutDelay(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	assert.Equal(t, epoch, <-clock.After(0))
+	assert.Equal(t, epoch, <-clock.After(-time.Second))
+	assert.Equal(t, 0, clock.Waiters())
+}
+
+func TestFakeClockBlockUntil(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	woken := make(chan time.Time)
+	go func() {
+		woken <- <-clock.After(time.Minute)
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	assert.Equal(t, epoch.Add(time.Minute), <-woken)
+}
+
+func TestZeroFakeClock(t *testing.T) {
+	var clock FakeClock
+	go clock.After(time.Second)
+	clock.BlockUntil(1)
+	assert.Equal(t, time.Time{}, clock.Now())
+}
+
+func TestSystemClock(t *testing.T) {
+	before := time.Now()
+	fired := <-SystemClock.After(time.Millisecond)
+	assert.False(t, fired.Before(before))
+	assert.False(t, SystemClock.Now().Before(fired))
+}
@@ -0,0 +1,214 @@
+package timeutil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Returned by Parse when the value does not match any of the patterns
+var ErrNoPatternMatched = errors.New("value does not match any of the date patterns")
+
+// A date pattern in the style of Java's SimpleDateFormat, such as
+// "yyyy-MM-dd'T'HH:mm:ss.SSSZ", translated into the equivalent Go layout.
+// The supported pattern letters are:
+//
+//	y     year, "yy" is a two digit year
+//	M     month, "MMM" is the abbreviated name and "MMMM" the full name
+//	d     day of month
+//	E     day of week, "EEEE" is the full name
+//	a     AM/PM marker
+//	H     hour of day (0-23), always formatted with two digits
+//	h     hour in AM/PM (1-12)
+//	m     minute
+//	s     second
+//	S     fraction of a second, must follow a '.' or ','
+//	z     time zone abbreviation
+//	Z     RFC 822 time zone, such as -0800
+//	X     ISO-8601 time zone, such as Z, -08, -0800 or -08:00
+//
+// Text in single quotes is copied as is.  A DateFormat is safe for use by
+// multiple goroutines.
+type DateFormat struct {
+	pattern string
+	layout  string
+}
+
+var dateFormatCache sync.Map
+
+// Returns the DateFormat for the pattern, compiled patterns are cached
+func CompileDateFormat(pattern string) (*DateFormat, error) {
+	if cached, ok := dateFormatCache.Load(pattern); ok {
+		return cached.(*DateFormat), nil
+	}
+
+	layout, err := translate(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date pattern %q: %w", pattern, err)
+	}
+
+	format, _ := dateFormatCache.LoadOrStore(pattern, &DateFormat{pattern: pattern, layout: layout})
+	return format.(*DateFormat), nil
+}
+
+// Returns the Java style pattern
+func (f *DateFormat) Pattern() string {
+	return f.pattern
+}
+
+// Returns the equivalent Go layout
+func (f *DateFormat) Layout() string {
+	return f.layout
+}
+
+// Formats the time using the pattern
+func (f *DateFormat) Format(t time.Time) string {
+	return t.Format(f.layout)
+}
+
+// Parses the value using the pattern, values without a time zone are in UTC
+func (f *DateFormat) Parse(value string) (time.Time, error) {
+	return time.Parse(f.layout, value)
+}
+
+// Parses the value using the pattern, values without a time zone are in the location provided
+func (f *DateFormat) ParseInLocation(value string, loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation(f.layout, value, loc)
+}
+
+// Formats the time using a Java style pattern
+func Format(t time.Time, pattern string) (string, error) {
+	format, err := CompileDateFormat(pattern)
+	if err != nil {
+		return "", err
+	}
+	return format.Format(t), nil
+}
+
+// Parses the value using the first of the Java style patterns that matches
+// and returns the time along with the pattern that matched.  Values without a
+// time zone are in UTC.
+func Parse(value string, patterns ...string) (time.Time, string, error) {
+	return ParseInLocation(value, time.UTC, patterns...)
+}
+
+// Parses the value using the first of the Java style patterns that matches
+// and returns the time along with the pattern that matched.  Values without a
+// time zone are in the location provided.
+func ParseInLocation(value string, loc *time.Location, patterns ...string) (time.Time, string, error) {
+	var failures []string
+	for _, pattern := range patterns {
+		format, err := CompileDateFormat(pattern)
+		if err != nil {
+			return time.Time{}, "", err
+		}
+		t, err := format.ParseInLocation(value, loc)
+		if err == nil {
+			return t, pattern, nil
+		}
+		failures = append(failures, fmt.Sprintf("%q", pattern))
+	}
+
+	return time.Time{}, "", fmt.Errorf("%w: %q does not match %s", ErrNoPatternMatched, value, strings.Join(failures, ", "))
+}
+
+// Translates a SimpleDateFormat pattern into a Go layout
+func translate(pattern string) (string, error) {
+	tokens, err := lex(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	// What the references are expected to format as, used to check that
+	// adjacent chunks do not combine into a different layout element
+	var layout, expectedA, expectedB strings.Builder
+	for i, t := range tokens {
+		if t.isLiteral() {
+			if !safeLiteral(t.literal) {
+				return "", fmt.Errorf("the text %q cannot be represented in a Go layout", t.literal)
+			}
+			layout.WriteString(t.literal)
+			expectedA.WriteString(t.literal)
+			expectedB.WriteString(t.literal)
+			continue
+		}
+
+		var chunk string
+		switch t.letter {
+		case 'y':
+			chunk = choose(t.count == 2, "06", "2006")
+		case 'M':
+			chunk = byCount(t.count, "1", "01", "Jan", "January")
+		case 'd':
+			chunk = byCount(t.count, "2", "02")
+		case 'E':
+			chunk = choose(t.count >= 4, "Monday", "Mon")
+		case 'a':
+			chunk = "PM"
+		case 'H':
+			chunk = "15"
+		case 'h':
+			chunk = byCount(t.count, "3", "03")
+		case 'm':
+			chunk = byCount(t.count, "4", "04")
+		case 's':
+			chunk = byCount(t.count, "5", "05")
+		case 'S':
+			if i == 0 || !tokens[i-1].isLiteral() || !strings.HasSuffix(tokens[i-1].literal, ".") && !strings.HasSuffix(tokens[i-1].literal, ",") {
+				return "", errors.New("'S' must follow a '.' or ','")
+			}
+			if t.count > 9 {
+				return "", errors.New("'S' supports at most 9 digits")
+			}
+			chunk = strings.Repeat("0", t.count)
+		case 'z':
+			chunk = "MST"
+		case 'Z':
+			chunk = "-0700"
+		case 'X':
+			chunk = byCount(t.count, "Z07", "Z0700", "Z07:00")
+		default:
+			return "", fmt.Errorf("unsupported pattern letter '%c'", t.letter)
+		}
+		layout.WriteString(chunk)
+		expectedA.WriteString(referenceA.Format(chunk))
+		expectedB.WriteString(referenceB.Format(chunk))
+	}
+
+	// Text such as "_" followed by a day, "_2", is read by Go as a single
+	// element, so the layout is only correct if it formats as its parts do
+	result := layout.String()
+	if referenceA.Format(result) != expectedA.String() || referenceB.Format(result) != expectedB.String() {
+		return "", fmt.Errorf("the pattern translates to the ambiguous Go layout %q", result)
+	}
+	return result, nil
+}
+
+func choose(condition bool, whenTrue string, whenFalse string) string {
+	if condition {
+		return whenTrue
+	}
+	return whenFalse
+}
+
+// Returns the chunk for the number of letters, longer runs use the last chunk
+func byCount(count int, chunks ...string) string {
+	if count > len(chunks) {
+		return chunks[len(chunks)-1]
+	}
+	return chunks[count-1]
+}
+
+var (
+	referenceA = time.Date(2001, 2, 3, 4, 5, 6, 0, time.FixedZone("AAA", 3600))
+	referenceB = time.Date(2012, 11, 22, 17, 18, 19, 0, time.FixedZone("BBB", -7200))
+)
+
+// Returns whether Go would copy the text unchanged rather than treat part of
+// it as a layout element.  Go layouts have no escape mechanism, so text such
+// as "1" or "Mon" cannot be used as a literal.
+func safeLiteral(text string) bool {
+	return referenceA.Format(text) == text && referenceB.Format(text) == text
+}
@@ -0,0 +1,150 @@
+package timeutil
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	value := time.Date(2022, 3, 7, 14, 5, 9, 123456789, time.FixedZone("CET", 3600))
+
+	var tests = map[string]struct {
+		pattern        string
+		expectedOutput string
+		expectedError  error
+	}{
+		"iso with millis":              {pattern: "yyyy-MM-dd'T'HH:mm:ss.SSSZ", expectedOutput: "2022-03-07T14:05:09.123+0100"},
+		"iso with colon zone":          {pattern: "yyyy-MM-dd'T'HH:mm:ssXXX", expectedOutput: "2022-03-07T14:05:09+01:00"},
+		"short year":                   {pattern: "yy/M/d", expectedOutput: "22/3/7"},
+		"month names":                  {pattern: "d MMM yyyy", expectedOutput: "7 Mar 2022"},
+		"full names":                   {pattern: "EEEE, MMMM d, yyyy", expectedOutput: "Monday, March 7, 2022"},
+		"short day":                    {pattern: "EEE", expectedOutput: "Mon"},
+		"twelve hour":                  {pattern: "h:mm a", expectedOutput: "2:05 PM"},
+		"padded twelve hour":           {pattern: "hh:mm:ss", expectedOutput: "02:05:09"},
+		"zone abbreviation":            {pattern: "HH:mm z", expectedOutput: "14:05 CET"},
+		"iso zone hours":               {pattern: "HHX", expectedOutput: "14+01"},
+		"nanoseconds":                  {pattern: "ss,SSSSSSSSS", expectedOutput: "09,123456789"},
+		"compact":                      {pattern: "yyyyMMddHHmmss", expectedOutput: "20220307140509"},
+		"quoted text":                  {pattern: "'at' HH 'o''clock'", expectedOutput: "at 14 o'clock"},
+		"escaped quote":                {pattern: "HH''mm", expectedOutput: "14'05"},
+		"unicode literal":              {pattern: "yyyy年M月d日", expectedOutput: "2022年3月7日"},
+		"unsafe literal":               {pattern: "yyyy 'Mon'", expectedError: errors.New(`invalid date pattern "yyyy 'Mon'": the text " Mon" cannot be represented in a Go layout`)},
+		"unsafe digit":                 {pattern: "yyyy'1'", expectedError: errors.New(`invalid date pattern "yyyy'1'": the text "1" cannot be represented in a Go layout`)},
+		"underscore before padded day": {pattern: "yyyy_MM_dd", expectedOutput: "2022_03_07"},
+		"underscore before day":        {pattern: "yyyy_MM_d", expectedError: errors.New(`invalid date pattern "yyyy_MM_d": the pattern translates to the ambiguous Go layout "2006_01_2"`)},
+		"zero before day":              {pattern: "'0'dd", expectedError: errors.New(`invalid date pattern "'0'dd": the pattern translates to the ambiguous Go layout "002"`)},
+		"text after month name":        {pattern: "MMM'uary'", expectedError: errors.New(`invalid date pattern "MMM'uary'": the pattern translates to the ambiguous Go layout "January"`)},
+		"unsupported letter":           {pattern: "yyyy G", expectedError: errors.New(`invalid date pattern "yyyy G": unsupported pattern letter 'G'`)},
+		"millis without dot":           {pattern: "ssSSS", expectedError: errors.New(`invalid date pattern "ssSSS": 'S' must follow a '.' or ','`)},
+		"unterminated quote":           {pattern: "yyyy 'T", expectedError: errors.New(`invalid date pattern "yyyy 'T": pattern contains an unterminated quote`)},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := Format(value, test.pattern)
+			if test.expectedError != nil {
+				assert.Equal(t, "", actual)
+				assert.EqualError(t, err, test.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, test.expectedOutput, actual)
+			}
+		})
+	}
+}
+
+func TestCompileDateFormat(t *testing.T) {
+	format, err := CompileDateFormat("yyyy-MM-dd'T'HH:mm:ss.SSSXXX")
+	assert.Nil(t, err)
+	assert.Equal(t, "2006-01-02T15:04:05.000Z07:00", format.Layout())
+	assert.Equal(t, "yyyy-MM-dd'T'HH:mm:ss.SSSXXX", format.Pattern())
+
+	again, _ := CompileDateFormat("yyyy-MM-dd'T'HH:mm:ss.SSSXXX")
+	assert.Same(t, format, again)
+
+	value := time.Date(2022, 3, 7, 14, 5, 9, 123000000, time.UTC)
+	assert.Equal(t, "2022-03-07T14:05:09.123Z", format.Format(value))
+	parsed, err := format.Parse("2022-03-07T14:05:09.123Z")
+	assert.Nil(t, err)
+	assert.True(t, value.Equal(parsed))
+}
+
+func TestCompileDateFormatConcurrently(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			format, err := CompileDateFormat("dd.MM.yyyy HH:mm")
+			assert.Nil(t, err)
+			assert.Equal(t, "02.01.2006 15:04", format.Layout())
+		}()
+	}
+	wg.Wait()
+}
+
+func TestParse(t *testing.T) {
+	patterns := []string{"yyyy-MM-dd'T'HH:mm:ss.SSSZ", "yyyy-MM-dd'T'HH:mm:ssXXX", "yyyy-MM-dd", "dd/MM/yyyy HH:mm"}
+
+	var tests = map[string]struct {
+		input           string
+		expectedTime    time.Time
+		expectedPattern string
+	}{
+		"first pattern": {
+			input:           "2022-03-07T14:05:09.123+0100",
+			expectedTime:    time.Date(2022, 3, 7, 13, 5, 9, 123000000, time.UTC),
+			expectedPattern: "yyyy-MM-dd'T'HH:mm:ss.SSSZ",
+		},
+		"second pattern": {
+			input:           "2022-03-07T14:05:09Z",
+			expectedTime:    time.Date(2022, 3, 7, 14, 5, 9, 0, time.UTC),
+			expectedPattern: "yyyy-MM-dd'T'HH:mm:ssXXX",
+		},
+		"date only": {
+			input:           "2022-03-07",
+			expectedTime:    time.Date(2022, 3, 7, 0, 0, 0, 0, time.UTC),
+			expectedPattern: "yyyy-MM-dd",
+		},
+		"european": {
+			input:           "07/03/2022 14:05",
+			expectedTime:    time.Date(2022, 3, 7, 14, 5, 0, 0, time.UTC),
+			expectedPattern: "dd/MM/yyyy HH:mm",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, pattern, err := Parse(test.input, patterns...)
+			assert.Nil(t, err)
+			assert.True(t, test.expectedTime.Equal(actual), actual.String())
+			assert.Equal(t, test.expectedPattern, pattern)
+		})
+	}
+}
+
+func TestParseInLocation(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+
+	actual, pattern, err := ParseInLocation("2022-07-04 09:30", newYork, "yyyy-MM-dd HH:mm")
+	assert.Nil(t, err)
+	assert.Equal(t, "yyyy-MM-dd HH:mm", pattern)
+	assert.Equal(t, time.Date(2022, 7, 4, 9, 30, 0, 0, newYork), actual)
+}
+
+func TestParseFailures(t *testing.T) {
+	_, pattern, err := Parse("not a date", "yyyy-MM-dd", "dd/MM/yyyy")
+	assert.ErrorIs(t, err, ErrNoPatternMatched)
+	assert.Equal(t, "", pattern)
+	assert.EqualError(t, err, `value does not match any of the date patterns: "not a date" does not match "yyyy-MM-dd", "dd/MM/yyyy"`)
+
+	_, _, err = Parse("2022", "yyyy Q")
+	assert.EqualError(t, err, `invalid date pattern "yyyy Q": unsupported pattern letter 'Q'`)
+
+	_, _, err = Parse("2022")
+	assert.ErrorIs(t, err, ErrNoPatternMatched)
+}
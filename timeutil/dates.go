@@ -0,0 +1,277 @@
+package timeutil
+
+import (
+	"fmt"
+	"time"
+)
+
+// A calendar unit used to truncate, round and step through times
+type Unit int
+
+const (
+	Seconds Unit = iota
+	Minutes
+	Hours
+	Days
+	// Weeks start on Monday
+	Weeks
+	Months
+	Years
+)
+
+func (u Unit) String() string {
+	switch u {
+	case Seconds:
+		return "seconds"
+	case Minutes:
+		return "minutes"
+	case Hours:
+		return "hours"
+	case Days:
+		return "days"
+	case Weeks:
+		return "weeks"
+	case Months:
+		return "months"
+	case Years:
+		return "years"
+	}
+	return fmt.Sprintf("Unit(%d)", int(u))
+}
+
+// Returns the time elapsed on the wall clock since the start of the unit,
+// similar to the Apache Commons DateUtils getFragment methods.  For example
+// the fragment of 03:15 in Days is 3h15m, even on a day where the clocks
+// moved forward at 02:00.
+func Fragment(t time.Time, unit Unit) time.Duration {
+	year, month, day := t.Date()
+	hour, minute, second := t.Clock()
+	nanos := time.Duration(t.Nanosecond())
+
+	switch unit {
+	case Seconds:
+		return nanos
+	case Minutes:
+		return time.Duration(second)*time.Second + nanos
+	case Hours:
+		return time.Duration(minute)*time.Minute + time.Duration(second)*time.Second + nanos
+	}
+
+	inDay := time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second + nanos
+	switch unit {
+	case Days:
+		return inDay
+	case Weeks:
+		return time.Duration(daysSinceMonday(t))*Day + inDay
+	case Months:
+		return time.Duration(day-1)*Day + inDay
+	case Years:
+		return time.Duration(daysBetween(time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(year, month, day, 0, 0, 0, 0, time.UTC)))*Day + inDay
+	}
+	return 0
+}
+
+func daysSinceMonday(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
+
+// Returns the start of the unit containing t, in the location of t.  Units
+// of an hour or less are truncated by subtracting the wall clock time spent
+// in the unit, so an hour that is repeated when clocks move back truncates to
+// the start of the correct occurrence.
+func Truncate(t time.Time, unit Unit) time.Time {
+	switch unit {
+	case Seconds, Minutes, Hours:
+		return t.Add(-Fragment(t, unit))
+	case Days:
+		return startOfDay(t.Year(), t.Month(), t.Day(), t.Location())
+	case Weeks:
+		return startOfDay(t.Year(), t.Month(), t.Day()-daysSinceMonday(t), t.Location())
+	case Months:
+		return startOfDay(t.Year(), t.Month(), 1, t.Location())
+	case Years:
+		return startOfDay(t.Year(), time.January, 1, t.Location())
+	}
+	return t
+}
+
+// Returns the first instant of the day in the location.  In zones where the
+// clocks move forward at midnight, such as America/Sao_Paulo, midnight does
+// not exist and the day starts at the moment the clocks change.
+func startOfDay(year int, month time.Month, day int, loc *time.Location) time.Time {
+	midnight := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if !dateOf(midnight).Before(date) {
+		return midnight
+	}
+
+	// time.Date normalized the missing midnight into the previous day, so
+	// search for the first instant of the requested day
+	before, after := midnight, midnight.Add(Day)
+	for after.Sub(before) > 1 {
+		middle := before.Add(after.Sub(before) / 2)
+		if dateOf(middle).Before(date) {
+			before = middle
+		} else {
+			after = middle
+		}
+	}
+	return after
+}
+
+// Returns the calendar date of the time as midnight UTC
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Returns the start of the next unit after t, or t itself when it is
+// already at the start of a unit
+func Ceiling(t time.Time, unit Unit) time.Time {
+	truncated := Truncate(t, unit)
+	if truncated.Equal(t) {
+		return t
+	}
+	return next(truncated, unit)
+}
+
+// Returns the start of the unit nearest to t, rounding halfway values up.
+// The halfway point is measured in elapsed time so that days with a
+// daylight saving change round correctly.
+func Round(t time.Time, unit Unit) time.Time {
+	truncated := Truncate(t, unit)
+	following := next(truncated, unit)
+	if t.Sub(truncated) >= following.Sub(truncated)-t.Sub(truncated) {
+		return following
+	}
+	return truncated
+}
+
+// Returns the start of the unit following the truncated time provided
+func next(truncated time.Time, unit Unit) time.Time {
+	switch unit {
+	case Seconds:
+		return truncated.Add(time.Second)
+	case Minutes:
+		return truncated.Add(time.Minute)
+	case Hours:
+		return truncated.Add(time.Hour)
+	}
+
+	// the following start is found from the date, as adding a day to a
+	// midnight that is followed by a missing midnight lands on the same day
+	year, month, day := truncated.Date()
+	switch unit {
+	case Days:
+		return startOfDay(year, month, day+1, truncated.Location())
+	case Weeks:
+		return startOfDay(year, month, day+7, truncated.Location())
+	case Months:
+		return startOfDay(year, month+1, 1, truncated.Location())
+	case Years:
+		return startOfDay(year+1, time.January, 1, truncated.Location())
+	}
+	return truncated
+}
+
+// Adds an amount of the unit to t.  Days, weeks, months and years keep the
+// wall clock time, so adding one day across a daylight saving change adds 23
+// or 25 hours.  Months and years are clamped to the end of the month.
+func Add(t time.Time, unit Unit, amount int) time.Time {
+	switch unit {
+	case Seconds:
+		return t.Add(time.Duration(amount) * time.Second)
+	case Minutes:
+		return t.Add(time.Duration(amount) * time.Minute)
+	case Hours:
+		return t.Add(time.Duration(amount) * time.Hour)
+	case Days:
+		return t.AddDate(0, 0, amount)
+	case Weeks:
+		return t.AddDate(0, 0, 7*amount)
+	case Months:
+		return AddMonths(t, amount)
+	case Years:
+		return AddYears(t, amount)
+	}
+	return t
+}
+
+// Adds months to t, clamping the day to the end of the resulting month so
+// that January 31st plus one month is the last day of February
+func AddMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	hour, minute, second := t.Clock()
+
+	first := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	if last := daysIn(first.Year(), first.Month()); day > last {
+		day = last
+	}
+	return time.Date(first.Year(), first.Month(), day, hour, minute, second, t.Nanosecond(), t.Location())
+}
+
+// Adds years to t, clamping February 29th to February 28th in years that are not leap years
+func AddYears(t time.Time, years int) time.Time {
+	return AddMonths(t, 12*years)
+}
+
+// Returns whether the times fall on the same calendar day in the location of a
+func IsSameDay(a time.Time, b time.Time) bool {
+	b = b.In(a.Location())
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
+
+// Returns whether the times represent the same instant, regardless of location
+func IsSameInstant(a time.Time, b time.Time) bool {
+	return a.Equal(b)
+}
+
+// Steps through the times between a start and end time one unit at a time.
+//
+//	it := Range(start, end, Days)
+//	for it.Next() {
+//		fmt.Println(it.Time())
+//	}
+type Iterator struct {
+	start time.Time
+	end   time.Time
+	unit  Unit
+	index int
+	time  time.Time
+}
+
+// Returns an iterator over start, start plus one unit, start plus two units
+// and so on while the values are not after end.  Each value is calculated
+// from start so month end clamping does not accumulate: stepping monthly
+// from January 31st yields February 28th and then March 31st.  Panics when
+// the unit is not one of the Unit constants.
+func Range(start time.Time, end time.Time, unit Unit) *Iterator {
+	if unit < Seconds || unit > Years {
+		panic(fmt.Sprintf("Range: unknown unit %v", unit))
+	}
+	return &Iterator{start: start, end: end, unit: unit}
+}
+
+// Advances to the next time, returning false when the end has been passed
+func (it *Iterator) Next() bool {
+	candidate := Add(it.start, it.unit, it.index)
+	if candidate.After(it.end) {
+		return false
+	}
+	it.time = candidate
+	it.index++
+	return true
+}
+
+// Returns the current time of the iterator
+func (it *Iterator) Time() time.Time {
+	return it.time
+}
+
+// Returns all remaining times of the iterator
+func (it *Iterator) Collect() []time.Time {
+	var times []time.Time
+	for it.Next() {
+		times = append(times, it.Time())
+	}
+	return times
+}
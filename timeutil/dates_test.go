@@ -0,0 +1,244 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestTruncate(t *testing.T) {
+	for _, name := range []string{"UTC", "America/New_York", "Asia/Kolkata", "Australia/Sydney"} {
+		loc := mustLoad(t, name)
+		// a Thursday
+		value := time.Date(2022, 6, 16, 14, 35, 47, 123456789, loc)
+
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, time.Date(2022, 6, 16, 14, 35, 47, 0, loc), Truncate(value, Seconds))
+			assert.Equal(t, time.Date(2022, 6, 16, 14, 35, 0, 0, loc), Truncate(value, Minutes))
+			assert.Equal(t, time.Date(2022, 6, 16, 14, 0, 0, 0, loc), Truncate(value, Hours))
+			assert.Equal(t, time.Date(2022, 6, 16, 0, 0, 0, 0, loc), Truncate(value, Days))
+			assert.Equal(t, time.Date(2022, 6, 13, 0, 0, 0, 0, loc), Truncate(value, Weeks))
+			assert.Equal(t, time.Date(2022, 6, 1, 0, 0, 0, 0, loc), Truncate(value, Months))
+			assert.Equal(t, time.Date(2022, 1, 1, 0, 0, 0, 0, loc), Truncate(value, Years))
+		})
+	}
+}
+
+func TestTruncateAcrossDST(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+
+	// clocks moved forward from 02:00 to 03:00 on 2022-03-13
+	springForward := time.Date(2022, 3, 13, 3, 15, 0, 0, newYork)
+	assert.Equal(t, time.Date(2022, 3, 13, 3, 0, 0, 0, newYork), Truncate(springForward, Hours))
+	assert.Equal(t, time.Date(2022, 3, 13, 0, 0, 0, 0, newYork), Truncate(springForward, Days))
+	assert.Equal(t, 3*time.Hour+15*time.Minute, Fragment(springForward, Days))
+
+	// clocks moved back from 02:00 to 01:00 on 2022-11-06, so 01:30 happens twice
+	firstOneThirty := time.Date(2022, 11, 6, 5, 30, 0, 0, time.UTC).In(newYork)
+	secondOneThirty := firstOneThirty.Add(time.Hour)
+	assert.Equal(t, firstOneThirty.Add(-30*time.Minute), Truncate(firstOneThirty, Hours))
+	assert.Equal(t, secondOneThirty.Add(-30*time.Minute), Truncate(secondOneThirty, Hours))
+	assert.Equal(t, "01:00:00 EST", Truncate(secondOneThirty, Hours).Format("15:04:05 MST"))
+}
+
+func TestMidnightDST(t *testing.T) {
+	var tests = map[string]struct {
+		zone string
+		// the day on which the clocks moved forward from 00:00 to 01:00
+		year  int
+		month time.Month
+		day   int
+	}{
+		"sao paulo": {zone: "America/Sao_Paulo", year: 2018, month: time.November, day: 4},
+		"havana":    {zone: "America/Havana", year: 2022, month: time.March, day: 13},
+		"beirut":    {zone: "Asia/Beirut", year: 2022, month: time.March, day: 27},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			loc := mustLoad(t, test.zone)
+			start := time.Date(test.year, test.month, test.day, 1, 0, 0, 0, loc)
+			previousNoon := time.Date(test.year, test.month, test.day-1, 12, 0, 0, 0, loc)
+			noon := time.Date(test.year, test.month, test.day, 12, 0, 0, 0, loc)
+
+			assert.NotEqual(t, test.day, start.Add(-time.Nanosecond).Day(), "the day starts at 01:00")
+			assert.True(t, start.Equal(Truncate(noon, Days)), Truncate(noon, Days))
+			assert.True(t, start.Equal(Ceiling(previousNoon, Days)), Ceiling(previousNoon, Days))
+			assert.True(t, start.Equal(Round(previousNoon.Add(11*time.Hour), Days)))
+			assert.True(t, start.Equal(Truncate(start, Days)))
+			assert.True(t, start.Equal(Ceiling(start, Days)))
+			assert.Equal(t, test.day, Truncate(noon, Days).Day())
+		})
+	}
+}
+
+func TestCeiling(t *testing.T) {
+	loc := mustLoad(t, "Europe/London")
+	value := time.Date(2022, 12, 31, 14, 35, 47, 0, loc)
+
+	assert.Equal(t, time.Date(2022, 12, 31, 14, 36, 0, 0, loc), Ceiling(value, Minutes))
+	assert.Equal(t, time.Date(2022, 12, 31, 15, 0, 0, 0, loc), Ceiling(value, Hours))
+	assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, loc), Ceiling(value, Days))
+	assert.Equal(t, time.Date(2023, 1, 2, 0, 0, 0, 0, loc), Ceiling(value, Weeks))
+	assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, loc), Ceiling(value, Months))
+	assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, loc), Ceiling(value, Years))
+
+	exact := time.Date(2022, 12, 1, 0, 0, 0, 0, loc)
+	assert.Equal(t, exact, Ceiling(exact, Months))
+	assert.Equal(t, exact, Ceiling(exact, Days))
+}
+
+func TestRound(t *testing.T) {
+	loc := mustLoad(t, "Australia/Sydney")
+
+	var tests = map[string]struct {
+		value    time.Time
+		unit     Unit
+		expected time.Time
+	}{
+		"seconds down": {value: time.Date(2022, 1, 1, 0, 0, 1, 499_000_000, loc), unit: Seconds, expected: time.Date(2022, 1, 1, 0, 0, 1, 0, loc)},
+		"seconds half": {value: time.Date(2022, 1, 1, 0, 0, 1, 500_000_000, loc), unit: Seconds, expected: time.Date(2022, 1, 1, 0, 0, 2, 0, loc)},
+		"minutes up":   {value: time.Date(2022, 1, 1, 0, 10, 30, 0, loc), unit: Minutes, expected: time.Date(2022, 1, 1, 0, 11, 0, 0, loc)},
+		"hours down":   {value: time.Date(2022, 1, 1, 10, 29, 59, 0, loc), unit: Hours, expected: time.Date(2022, 1, 1, 10, 0, 0, 0, loc)},
+		"days up":      {value: time.Date(2022, 1, 1, 12, 0, 0, 0, loc), unit: Days, expected: time.Date(2022, 1, 2, 0, 0, 0, 0, loc)},
+		"days down":    {value: time.Date(2022, 1, 1, 11, 59, 0, 0, loc), unit: Days, expected: time.Date(2022, 1, 1, 0, 0, 0, 0, loc)},
+		"months up":    {value: time.Date(2022, 2, 15, 0, 0, 0, 0, loc), unit: Months, expected: time.Date(2022, 3, 1, 0, 0, 0, 0, loc)},
+		"months down":  {value: time.Date(2022, 1, 15, 0, 0, 0, 0, loc), unit: Months, expected: time.Date(2022, 1, 1, 0, 0, 0, 0, loc)},
+		"years up":     {value: time.Date(2022, 7, 3, 0, 0, 0, 0, loc), unit: Years, expected: time.Date(2023, 1, 1, 0, 0, 0, 0, loc)},
+		"weeks down":   {value: time.Date(2022, 6, 15, 23, 0, 0, 0, loc), unit: Weeks, expected: time.Date(2022, 6, 13, 0, 0, 0, 0, loc)},
+		// clocks moved back at 03:00 on 2022-04-03 so the day is 25 hours long
+		// and its elapsed midpoint is 11:30 on the wall clock
+		"long day before midpoint": {value: time.Date(2022, 4, 3, 11, 0, 0, 0, loc), unit: Days, expected: time.Date(2022, 4, 3, 0, 0, 0, 0, loc)},
+		"long day at midpoint":     {value: time.Date(2022, 4, 3, 11, 30, 0, 0, loc), unit: Days, expected: time.Date(2022, 4, 4, 0, 0, 0, 0, loc)},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Round(test.value, test.unit))
+		})
+	}
+}
+
+func TestAddMonths(t *testing.T) {
+	loc := mustLoad(t, "America/New_York")
+
+	var tests = map[string]struct {
+		value    time.Time
+		months   int
+		expected time.Time
+	}{
+		"simple":            {value: time.Date(2022, 1, 15, 9, 30, 0, 0, loc), months: 1, expected: time.Date(2022, 2, 15, 9, 30, 0, 0, loc)},
+		"end of month":      {value: time.Date(2022, 1, 31, 9, 30, 0, 0, loc), months: 1, expected: time.Date(2022, 2, 28, 9, 30, 0, 0, loc)},
+		"leap year":         {value: time.Date(2024, 1, 31, 0, 0, 0, 0, loc), months: 1, expected: time.Date(2024, 2, 29, 0, 0, 0, 0, loc)},
+		"thirty day month":  {value: time.Date(2022, 3, 31, 0, 0, 0, 0, loc), months: 1, expected: time.Date(2022, 4, 30, 0, 0, 0, 0, loc)},
+		"negative":          {value: time.Date(2022, 3, 31, 0, 0, 0, 0, loc), months: -1, expected: time.Date(2022, 2, 28, 0, 0, 0, 0, loc)},
+		"across years":      {value: time.Date(2022, 11, 30, 0, 0, 0, 0, loc), months: 3, expected: time.Date(2023, 2, 28, 0, 0, 0, 0, loc)},
+		"across dst":        {value: time.Date(2022, 2, 20, 12, 0, 0, 0, loc), months: 1, expected: time.Date(2022, 3, 20, 12, 0, 0, 0, loc)},
+		"backwards to year": {value: time.Date(2022, 1, 31, 0, 0, 0, 0, loc), months: -13, expected: time.Date(2020, 12, 31, 0, 0, 0, 0, loc)},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, AddMonths(test.value, test.months))
+		})
+	}
+
+	assert.Equal(t, time.Date(2023, 2, 28, 0, 0, 0, 0, loc), AddYears(time.Date(2024, 2, 29, 0, 0, 0, 0, loc), -1))
+}
+
+func TestAdd(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+	before := time.Date(2022, 3, 12, 12, 0, 0, 0, newYork)
+
+	assert.Equal(t, time.Date(2022, 3, 13, 12, 0, 0, 0, newYork), Add(before, Days, 1))
+	assert.Equal(t, 23*time.Hour, Add(before, Days, 1).Sub(before))
+	assert.Equal(t, 24*time.Hour, Add(before, Hours, 24).Sub(before))
+	assert.Equal(t, time.Date(2022, 3, 26, 12, 0, 0, 0, newYork), Add(before, Weeks, 2))
+	assert.Equal(t, before.Add(90*time.Second), Add(before, Seconds, 90))
+	assert.Equal(t, before.Add(-5*time.Minute), Add(before, Minutes, -5))
+	assert.Equal(t, time.Date(2023, 3, 12, 12, 0, 0, 0, newYork), Add(before, Years, 1))
+}
+
+func TestFragment(t *testing.T) {
+	value := time.Date(2022, 2, 3, 4, 5, 6, 7, time.UTC)
+
+	assert.Equal(t, time.Duration(7), Fragment(value, Seconds))
+	assert.Equal(t, 6*time.Second+7, Fragment(value, Minutes))
+	assert.Equal(t, 5*time.Minute+6*time.Second+7, Fragment(value, Hours))
+	assert.Equal(t, 4*time.Hour+5*time.Minute+6*time.Second+7, Fragment(value, Days))
+	// 2022-02-03 is a Thursday
+	assert.Equal(t, 3*Day+4*time.Hour+5*time.Minute+6*time.Second+7, Fragment(value, Weeks))
+	assert.Equal(t, 2*Day+4*time.Hour+5*time.Minute+6*time.Second+7, Fragment(value, Months))
+	assert.Equal(t, 33*Day+4*time.Hour+5*time.Minute+6*time.Second+7, Fragment(value, Years))
+	assert.Equal(t, int64(33), int64(Fragment(value, Years)/Day))
+}
+
+func TestIsSameDay(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+	tokyo := mustLoad(t, "Asia/Tokyo")
+
+	a := time.Date(2022, 6, 1, 23, 0, 0, 0, newYork)
+	b := time.Date(2022, 6, 1, 1, 0, 0, 0, newYork)
+	sameInstantInTokyo := a.In(tokyo)
+
+	assert.True(t, IsSameDay(a, b))
+	assert.True(t, IsSameDay(a, sameInstantInTokyo))
+	assert.False(t, IsSameDay(sameInstantInTokyo, b))
+	assert.False(t, IsSameDay(a, time.Date(2021, 6, 1, 23, 0, 0, 0, newYork)))
+
+	assert.True(t, IsSameInstant(a, sameInstantInTokyo))
+	assert.False(t, IsSameInstant(a, b))
+}
+
+func TestRange(t *testing.T) {
+	london := mustLoad(t, "Europe/London")
+
+	days := Range(time.Date(2022, 3, 26, 9, 0, 0, 0, london), time.Date(2022, 3, 28, 9, 0, 0, 0, london), Days).Collect()
+	assert.Equal(t, []time.Time{
+		time.Date(2022, 3, 26, 9, 0, 0, 0, london),
+		time.Date(2022, 3, 27, 9, 0, 0, 0, london),
+		time.Date(2022, 3, 28, 9, 0, 0, 0, london),
+	}, days)
+
+	weeks := Range(time.Date(2022, 1, 3, 0, 0, 0, 0, london), time.Date(2022, 1, 20, 0, 0, 0, 0, london), Weeks).Collect()
+	assert.Equal(t, []time.Time{
+		time.Date(2022, 1, 3, 0, 0, 0, 0, london),
+		time.Date(2022, 1, 10, 0, 0, 0, 0, london),
+		time.Date(2022, 1, 17, 0, 0, 0, 0, london),
+	}, weeks)
+
+	months := Range(time.Date(2022, 1, 31, 0, 0, 0, 0, london), time.Date(2022, 4, 30, 0, 0, 0, 0, london), Months).Collect()
+	assert.Equal(t, []time.Time{
+		time.Date(2022, 1, 31, 0, 0, 0, 0, london),
+		time.Date(2022, 2, 28, 0, 0, 0, 0, london),
+		time.Date(2022, 3, 31, 0, 0, 0, 0, london),
+		time.Date(2022, 4, 30, 0, 0, 0, 0, london),
+	}, months)
+
+	assert.Empty(t, Range(time.Date(2022, 1, 2, 0, 0, 0, 0, london), time.Date(2022, 1, 1, 0, 0, 0, 0, london), Days).Collect())
+
+	it := Range(time.Date(2022, 1, 1, 0, 0, 0, 0, london), time.Date(2022, 1, 1, 0, 0, 0, 0, london), Days)
+	assert.True(t, it.Next())
+	assert.Equal(t, time.Date(2022, 1, 1, 0, 0, 0, 0, london), it.Time())
+	assert.False(t, it.Next())
+}
+
+func TestRangeWithUnknownUnit(t *testing.T) {
+	now := time.Now()
+	assert.PanicsWithValue(t, "Range: unknown unit Unit(42)", func() { Range(now, now, Unit(42)) })
+}
+
+func TestUnitString(t *testing.T) {
+	assert.Equal(t, "days", Days.String())
+	assert.Equal(t, "Unit(42)", Unit(42).String())
+}
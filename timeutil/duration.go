@@ -0,0 +1,351 @@
+package timeutil
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Day  = 24 * time.Hour
+	Week = 7 * Day
+)
+
+// The pattern used by FormatDurationHMS
+const HMSPattern = "HH:mm:ss.SSS"
+
+// Formats a duration using a pattern similar to Apache Commons
+// DurationFormatUtils.  The pattern letters are:
+//
+//	d  days
+//	H  hours
+//	m  minutes
+//	s  seconds
+//	S  milliseconds
+//
+// Repeating a letter pads the value with zeros, so "HH:mm:ss.SSS" formats
+// 1h2m3.5s as "01:02:03.500".  Text in single quotes is copied as is.  A unit
+// that is not in the pattern is carried into the next smaller unit, so
+// "m 'minutes'" formats 2h as "120 minutes".  Negative durations are prefixed
+// with '-'.
+func FormatDuration(d time.Duration, pattern string) (string, error) {
+	tokens, err := lex(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	millis := int64(d / time.Millisecond)
+	values := map[byte]int64{}
+	for _, unit := range []struct {
+		letter byte
+		millis int64
+	}{
+		{'d', int64(Day / time.Millisecond)},
+		{'H', int64(time.Hour / time.Millisecond)},
+		{'m', int64(time.Minute / time.Millisecond)},
+		{'s', int64(time.Second / time.Millisecond)},
+	} {
+		if containsLetter(tokens, unit.letter) {
+			values[unit.letter] = millis / unit.millis
+			millis %= unit.millis
+		}
+	}
+	values['S'] = millis
+
+	result, err := formatTokens(tokens, values)
+	if err != nil {
+		return "", err
+	}
+	return sign + result, nil
+}
+
+// Formats a duration as "HH:mm:ss.SSS", hours are not limited to 24
+func FormatDurationHMS(d time.Duration) string {
+	result, _ := FormatDuration(d, HMSPattern)
+	return result
+}
+
+func formatTokens(tokens []token, values map[byte]int64) (string, error) {
+	var b strings.Builder
+	for _, t := range tokens {
+		if t.isLiteral() {
+			b.WriteString(t.literal)
+			continue
+		}
+		value, ok := values[t.letter]
+		if !ok {
+			return "", fmt.Errorf("unsupported pattern letter '%c'", t.letter)
+		}
+		b.WriteString(fmt.Sprintf("%0*d", t.count, value))
+	}
+	return b.String(), nil
+}
+
+// Formats a duration in words, such as "1 day 2 hours 3 minutes 4 seconds".
+// Leading and trailing units that are zero can be left out, a duration that
+// is entirely zero is formatted as "0 seconds".
+func FormatDurationWords(d time.Duration, suppressLeadingZeros bool, suppressTrailingZeros bool) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	units := []struct {
+		value int64
+		name  string
+	}{
+		{int64(d / Day), "day"},
+		{int64(d % Day / time.Hour), "hour"},
+		{int64(d % time.Hour / time.Minute), "minute"},
+		{int64(d % time.Minute / time.Second), "second"},
+	}
+
+	start, end := 0, len(units)
+	if suppressLeadingZeros {
+		for start < end && units[start].value == 0 {
+			start++
+		}
+	}
+	if suppressTrailingZeros {
+		for end > start && units[end-1].value == 0 {
+			end--
+		}
+	}
+	if start == end {
+		return "0 seconds"
+	}
+
+	words := make([]string, 0, end-start)
+	for _, unit := range units[start:end] {
+		words = append(words, pluralize(unit.value, unit.name))
+	}
+	return sign + strings.Join(words, " ")
+}
+
+func pluralize(value int64, name string) string {
+	if value == 1 {
+		return "1 " + name
+	}
+	return fmt.Sprintf("%d %ss", value, name)
+}
+
+// Formats a duration in the ISO-8601 form PnDTnHnMnS, such as "P1DT2H3M4.5S".
+// Units that are zero are left out and a zero duration is "PT0S".  Negative
+// durations are prefixed with '-'.
+func FormatISO8601(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	var b strings.Builder
+	abs := uint64(d)
+	if d < 0 {
+		b.WriteByte('-')
+		// negating math.MinInt64 overflows back to itself, which is still the
+		// correct magnitude once converted to unsigned
+		abs = uint64(-d)
+	}
+
+	days := abs / uint64(Day)
+	abs %= uint64(Day)
+	hours := abs / uint64(time.Hour)
+	abs %= uint64(time.Hour)
+	minutes := abs / uint64(time.Minute)
+	abs %= uint64(time.Minute)
+	seconds := abs / uint64(time.Second)
+	nanos := abs % uint64(time.Second)
+
+	b.WriteByte('P')
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+	if hours > 0 || minutes > 0 || seconds > 0 || nanos > 0 {
+		b.WriteByte('T')
+	}
+	if hours > 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+	}
+	if minutes > 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+	}
+	if seconds > 0 || nanos > 0 {
+		b.WriteString(strconv.FormatUint(seconds, 10))
+		if nanos > 0 {
+			b.WriteString(strings.TrimRight(fmt.Sprintf(".%09d", nanos), "0"))
+		}
+		b.WriteByte('S')
+	}
+	return b.String()
+}
+
+var iso8601Pattern = regexp.MustCompile(`^([-+])?P(?:([0-9.,]+)Y)?(?:([0-9.,]+)M)?(?:([0-9.,]+)W)?(?:([0-9.,]+)D)?(?:T(?:([0-9.,]+)H)?(?:([0-9.,]+)M)?(?:([0-9.,]+)S)?)?$`)
+
+// Returned when a string is not an ISO-8601 duration that can be converted to a time.Duration
+var ErrInvalidISO8601 = errors.New("invalid ISO-8601 duration")
+
+// Parses an ISO-8601 duration such as "P1DT2H3M4.5S" or "PT0.25S".  Weeks are
+// accepted and days are treated as 24 hours.  Years and months are rejected
+// since their length depends on the date they are applied to.
+func ParseISO8601(value string) (time.Duration, error) {
+	matches := iso8601Pattern.FindStringSubmatch(value)
+	if matches == nil || strings.HasSuffix(value, "T") || strings.Join(matches[2:], "") == "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidISO8601, value)
+	}
+	if matches[2] != "" || matches[3] != "" {
+		return 0, fmt.Errorf("%w: %q contains years or months, which do not have a fixed duration", ErrInvalidISO8601, value)
+	}
+
+	var total time.Duration
+	for i, unit := range []time.Duration{Week, Day, time.Hour, time.Minute, time.Second} {
+		number := matches[i+4]
+		if number == "" {
+			continue
+		}
+		d, err := scaleDecimal(strings.Replace(number, ",", ".", 1), unit)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q: %v", ErrInvalidISO8601, value, err)
+		}
+		if total > math.MaxInt64-d {
+			return 0, fmt.Errorf("%w: %q overflows time.Duration", ErrInvalidISO8601, value)
+		}
+		total += d
+	}
+
+	if matches[1] == "-" {
+		total = -total
+	}
+	return total, nil
+}
+
+// Multiplies a non-negative decimal number by the unit without losing precision to floating point
+func scaleDecimal(number string, unit time.Duration) (time.Duration, error) {
+	whole, fraction, _ := strings.Cut(number, ".")
+	if whole == "" && fraction == "" || strings.ContainsAny(fraction, ".,") {
+		return 0, fmt.Errorf("invalid number %q", number)
+	}
+
+	var d time.Duration
+	if whole != "" {
+		n, err := strconv.ParseInt(whole, 10, 64)
+		if err != nil || n > int64(math.MaxInt64/unit) {
+			return 0, fmt.Errorf("invalid number %q", number)
+		}
+		d = time.Duration(n) * unit
+	}
+
+	scale := unit
+	for _, digit := range fraction {
+		if digit < '0' || digit > '9' {
+			return 0, fmt.Errorf("invalid number %q", number)
+		}
+		scale /= 10
+		d += time.Duration(digit-'0') * scale
+	}
+	return d, nil
+}
+
+// Formats the calendar period between two times using a pattern.  The
+// pattern letters are those of FormatDuration plus y for years and M for
+// months.  The period is calculated from the calendar fields in the location
+// of start, so a period spanning a daylight saving change is still one day
+// and a period from January 31st to February 28th is "0 months 28 days".
+// Units missing from the pattern are carried into the next smaller unit.
+func FormatPeriod(start time.Time, end time.Time, pattern string) (string, error) {
+	tokens, err := lex(pattern)
+	if err != nil {
+		return "", err
+	}
+	if end.Before(start) {
+		return "", errors.New("the end time must not be before the start time")
+	}
+
+	end = end.In(start.Location())
+	years := int64(end.Year() - start.Year())
+	months := int64(end.Month() - start.Month())
+	days := int64(end.Day() - start.Day())
+	hours := int64(end.Hour() - start.Hour())
+	minutes := int64(end.Minute() - start.Minute())
+	seconds := int64(end.Second() - start.Second())
+	millis := int64(end.Nanosecond()/1e6 - start.Nanosecond()/1e6)
+
+	for millis < 0 {
+		millis += 1000
+		seconds--
+	}
+	for seconds < 0 {
+		seconds += 60
+		minutes--
+	}
+	for minutes < 0 {
+		minutes += 60
+		hours--
+	}
+	for hours < 0 {
+		hours += 24
+		days--
+	}
+	for days < 0 {
+		// borrow the length of the last whole month counted from start, which
+		// is the month the remaining days are spent in
+		months--
+		days += int64(daysIn(start.Year()+int(years), start.Month()+time.Month(months)))
+	}
+	for months < 0 {
+		months += 12
+		years--
+	}
+
+	if !containsLetter(tokens, 'y') {
+		months += years * 12
+		years = 0
+	}
+	if !containsLetter(tokens, 'M') {
+		from := start.AddDate(int(years), 0, 0)
+		to := start.AddDate(int(years), int(months), 0)
+		days += int64(daysBetween(from, to))
+		months = 0
+	}
+	if !containsLetter(tokens, 'd') {
+		hours += days * 24
+		days = 0
+	}
+	if !containsLetter(tokens, 'H') {
+		minutes += hours * 60
+		hours = 0
+	}
+	if !containsLetter(tokens, 'm') {
+		seconds += minutes * 60
+		minutes = 0
+	}
+	if !containsLetter(tokens, 's') {
+		millis += seconds * 1000
+		seconds = 0
+	}
+
+	return formatTokens(tokens, map[byte]int64{
+		'y': years, 'M': months, 'd': days, 'H': hours, 'm': minutes, 's': seconds, 'S': millis,
+	})
+}
+
+// Returns the number of days in the month, months outside 1-12 wrap into the adjacent year
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Returns the number of calendar days between the dates of two times
+func daysBetween(from time.Time, to time.Time) int {
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate) / Day)
+}
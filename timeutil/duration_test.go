@@ -0,0 +1,259 @@
+package timeutil
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDuration(t *testing.T) {
+	d := 26*time.Hour + 2*time.Minute + 3*time.Second + 500*time.Millisecond
+
+	var tests = map[string]struct {
+		duration       time.Duration
+		pattern        string
+		expectedOutput string
+		expectedError  error
+	}{
+		"hms":                      {duration: d, pattern: HMSPattern, expectedOutput: "26:02:03.500"},
+		"days":                     {duration: d, pattern: "d'd' HH:mm:ss", expectedOutput: "1d 02:02:03"},
+		"minutes only":             {duration: 2 * time.Hour, pattern: "m' minutes'", expectedOutput: "120 minutes"},
+		"seconds and millis":       {duration: d, pattern: "s.SSS", expectedOutput: "93723.500"},
+		"millis only":              {duration: time.Second, pattern: "S", expectedOutput: "1000"},
+		"no padding":               {duration: 5 * time.Minute, pattern: "H:m:s", expectedOutput: "0:5:0"},
+		"zero":                     {duration: 0, pattern: HMSPattern, expectedOutput: "00:00:00.000"},
+		"negative":                 {duration: -90 * time.Second, pattern: "mm:ss", expectedOutput: "-01:30"},
+		"quoted letters":           {duration: time.Minute, pattern: "m 'm''s'", expectedOutput: "1 m's"},
+		"escaped quote":            {duration: time.Minute, pattern: "m''", expectedOutput: "1'"},
+		"unterminated quote":       {duration: time.Minute, pattern: "m 'minutes", expectedError: errors.New("pattern contains an unterminated quote")},
+		"unsupported letter":       {duration: time.Minute, pattern: "yyyy", expectedError: errors.New("unsupported pattern letter 'y'")},
+		"nanoseconds are dropped":  {duration: time.Millisecond + 999*time.Microsecond, pattern: "SSS", expectedOutput: "001"},
+		"padding wider than value": {duration: 3 * time.Second, pattern: "sssss", expectedOutput: "00003"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := FormatDuration(test.duration, test.pattern)
+			if test.expectedError != nil {
+				assert.Equal(t, "", actual)
+				assert.Equal(t, test.expectedError, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, test.expectedOutput, actual)
+			}
+		})
+	}
+}
+
+func TestFormatDurationHMS(t *testing.T) {
+	assert.Equal(t, "01:02:03.500", FormatDurationHMS(time.Hour+2*time.Minute+3500*time.Millisecond))
+}
+
+func TestFormatDurationWords(t *testing.T) {
+	var tests = map[string]struct {
+		duration         time.Duration
+		suppressLeading  bool
+		suppressTrailing bool
+		expected         string
+	}{
+		"all units":                  {duration: 26*time.Hour + 3*time.Minute + 4*time.Second, expected: "1 day 2 hours 3 minutes 4 seconds"},
+		"zero units kept":            {duration: 3 * time.Minute, expected: "0 days 0 hours 3 minutes 0 seconds"},
+		"suppress leading":           {duration: 3 * time.Minute, suppressLeading: true, expected: "3 minutes 0 seconds"},
+		"suppress trailing":          {duration: 26*time.Hour + 3*time.Minute, suppressTrailing: true, expected: "1 day 2 hours 3 minutes"},
+		"suppress both":              {duration: 3 * time.Minute, suppressLeading: true, suppressTrailing: true, expected: "3 minutes"},
+		"inner zeros kept":           {duration: 24*time.Hour + time.Second, suppressLeading: true, suppressTrailing: true, expected: "1 day 0 hours 0 minutes 1 second"},
+		"zero":                       {duration: 0, suppressLeading: true, suppressTrailing: true, expected: "0 seconds"},
+		"zero without suppression":   {duration: 0, expected: "0 days 0 hours 0 minutes 0 seconds"},
+		"singular":                   {duration: 25*time.Hour + time.Minute + time.Second, expected: "1 day 1 hour 1 minute 1 second"},
+		"negative":                   {duration: -2 * time.Minute, suppressLeading: true, suppressTrailing: true, expected: "-2 minutes"},
+		"milliseconds are truncated": {duration: 1999 * time.Millisecond, suppressLeading: true, expected: "1 second"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, FormatDurationWords(test.duration, test.suppressLeading, test.suppressTrailing))
+		})
+	}
+}
+
+func TestFormatISO8601(t *testing.T) {
+	var tests = map[string]struct {
+		duration time.Duration
+		expected string
+	}{
+		"zero":         {duration: 0, expected: "PT0S"},
+		"all units":    {duration: 26*time.Hour + 3*time.Minute + 4500*time.Millisecond, expected: "P1DT2H3M4.5S"},
+		"days only":    {duration: 2 * Day, expected: "P2D"},
+		"minutes only": {duration: 90 * time.Second, expected: "PT1M30S"},
+		"fraction":     {duration: 250 * time.Millisecond, expected: "PT0.25S"},
+		"nanoseconds":  {duration: 1, expected: "PT0.000000001S"},
+		"negative":     {duration: -time.Hour, expected: "-PT1H"},
+		"minimum":      {duration: math.MinInt64, expected: "-P106751DT23H47M16.854775808S"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, FormatISO8601(test.duration))
+		})
+	}
+}
+
+func TestParseISO8601(t *testing.T) {
+	var tests = map[string]struct {
+		input    string
+		expected time.Duration
+		valid    bool
+	}{
+		"zero":               {input: "PT0S", expected: 0, valid: true},
+		"all units":          {input: "P1DT2H3M4.5S", expected: 26*time.Hour + 3*time.Minute + 4500*time.Millisecond, valid: true},
+		"weeks":              {input: "P2W", expected: 2 * Week, valid: true},
+		"fractional hours":   {input: "PT1.5H", expected: 90 * time.Minute, valid: true},
+		"comma fraction":     {input: "PT0,25S", expected: 250 * time.Millisecond, valid: true},
+		"negative":           {input: "-PT1M", expected: -time.Minute, valid: true},
+		"positive sign":      {input: "+PT1M", expected: time.Minute, valid: true},
+		"nanoseconds":        {input: "PT0.000000001S", expected: 1, valid: true},
+		"years":              {input: "P1Y", valid: false},
+		"months":             {input: "P1M", valid: false},
+		"empty":              {input: "", valid: false},
+		"no units":           {input: "P", valid: false},
+		"dangling time":      {input: "P1DT", valid: false},
+		"missing designator": {input: "1D", valid: false},
+		"wrong order":        {input: "PT1S1M", valid: false},
+		"two decimal points": {input: "PT1.2.3S", valid: false},
+		"lonely point":       {input: "PT.S", valid: false},
+		"overflow":           {input: "P9999999999D", valid: false},
+		"lower case":         {input: "pt1s", valid: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := ParseISO8601(test.input)
+			if test.valid {
+				assert.Nil(t, err)
+				assert.Equal(t, test.expected, actual)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidISO8601)
+			}
+		})
+	}
+}
+
+func TestISO8601RoundTrip(t *testing.T) {
+	for _, d := range []time.Duration{0, 1, time.Second, 90 * time.Minute, 3*Day + 250*time.Millisecond, -5 * time.Hour} {
+		parsed, err := ParseISO8601(FormatISO8601(d))
+		assert.Nil(t, err)
+		assert.Equal(t, d, parsed)
+	}
+}
+
+func TestFormatPeriod(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+
+	var tests = map[string]struct {
+		start, end time.Time
+		pattern    string
+		expected   string
+	}{
+		"years months days": {
+			start:    time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2022, 3, 20, 0, 0, 0, 0, time.UTC),
+			pattern:  "y'y' M'm' d'd'",
+			expected: "2y 2m 5d",
+		},
+		"borrow days from the month before the end": {
+			start:    time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC),
+			pattern:  "M'm' d'd'",
+			expected: "0m 28d",
+		},
+		"borrow days across the end of a month": {
+			start:    time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+			pattern:  "M'm' d'd'",
+			expected: "0m 29d",
+		},
+		"borrow days across the end of a month in a leap year": {
+			start:    time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			pattern:  "M'm' d'd'",
+			expected: "0m 30d",
+		},
+		"borrow days from february 29th": {
+			start:    time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2024, 3, 28, 0, 0, 0, 0, time.UTC),
+			pattern:  "M'm' d'd'",
+			expected: "0m 28d",
+		},
+		"borrow days from february 28th": {
+			start:    time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2023, 4, 27, 0, 0, 0, 0, time.UTC),
+			pattern:  "M'm' d'd'",
+			expected: "1m 30d",
+		},
+		"borrow days across the end of a month and a year": {
+			start:    time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+			pattern:  "y'y' M'm' d'd'",
+			expected: "0y 1m 29d",
+		},
+		"borrow across years": {
+			start:    time.Date(2021, 11, 20, 22, 0, 0, 0, time.UTC),
+			end:      time.Date(2022, 1, 5, 1, 30, 0, 0, time.UTC),
+			pattern:  "y M d H m",
+			expected: "0 1 15 3 30",
+		},
+		"months folded into days": {
+			start:    time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+			pattern:  "d",
+			expected: "59",
+		},
+		"years folded into months": {
+			start:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+			pattern:  "M",
+			expected: "26",
+		},
+		"milliseconds": {
+			start:    time.Date(2022, 1, 1, 0, 0, 0, 900_000_000, time.UTC),
+			end:      time.Date(2022, 1, 1, 0, 0, 1, 100_000_000, time.UTC),
+			pattern:  "s.SSS",
+			expected: "0.200",
+		},
+		"one day across daylight saving": {
+			start:    time.Date(2022, 3, 12, 12, 0, 0, 0, newYork),
+			end:      time.Date(2022, 3, 13, 12, 0, 0, 0, newYork),
+			pattern:  "d H",
+			expected: "1 0",
+		},
+		"end in another zone": {
+			start:    time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2022, 1, 1, 1, 0, 0, 0, newYork),
+			pattern:  "HH:mm",
+			expected: "06:00",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := FormatPeriod(test.start, test.end, test.pattern)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestFormatPeriodErrors(t *testing.T) {
+	now := time.Now()
+
+	_, err := FormatPeriod(now, now.Add(-time.Second), "s")
+	assert.EqualError(t, err, "the end time must not be before the start time")
+
+	_, err = FormatPeriod(now, now, "'s")
+	assert.EqualError(t, err, "pattern contains an unterminated quote")
+}
@@ -0,0 +1,90 @@
+package timeutil
+
+import (
+	"errors"
+	"strings"
+)
+
+// A piece of a pattern, either a run of a single pattern letter such as
+// "yyyy" or a literal piece of text
+type token struct {
+	letter  byte
+	count   int
+	literal string
+}
+
+func (t token) isLiteral() bool {
+	return t.letter == 0
+}
+
+var errUnterminatedQuote = errors.New("pattern contains an unterminated quote")
+
+// Splits a pattern in the style of Java's SimpleDateFormat into tokens.
+// Letters are pattern letters unless they are enclosed in single quotes, and
+// two consecutive single quotes represent a quote character.
+func lex(pattern string) ([]token, error) {
+	var tokens []token
+	var literal strings.Builder
+
+	flush := func() {
+		if literal.Len() > 0 {
+			tokens = append(tokens, token{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\'':
+			if i+1 < len(pattern) && pattern[i+1] == '\'' {
+				literal.WriteByte('\'')
+				i++
+				continue
+			}
+			end := i + 1
+			for {
+				if end >= len(pattern) {
+					return nil, errUnterminatedQuote
+				}
+				if pattern[end] == '\'' {
+					if end+1 < len(pattern) && pattern[end+1] == '\'' {
+						literal.WriteByte('\'')
+						end += 2
+						continue
+					}
+					break
+				}
+				literal.WriteByte(pattern[end])
+				end++
+			}
+			i = end
+		case isPatternLetter(c):
+			flush()
+			count := 1
+			for i+1 < len(pattern) && pattern[i+1] == c {
+				count++
+				i++
+			}
+			tokens = append(tokens, token{letter: c, count: count})
+		default:
+			literal.WriteByte(c)
+		}
+	}
+	flush()
+
+	return tokens, nil
+}
+
+func isPatternLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func containsLetter(tokens []token, letter byte) bool {
+	for _, t := range tokens {
+		if t.letter == letter {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,334 @@
+package timeutil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Returned, wrapped with a description, when a StopWatch method is called in
+// a state that does not allow it, such as stopping a stopwatch that was
+// never started
+var ErrIllegalState = errors.New("illegal stopwatch state")
+
+type stopWatchState int
+
+const (
+	unstarted stopWatchState = iota
+	running
+	stopped
+	suspended
+)
+
+func (s stopWatchState) String() string {
+	switch s {
+	case running:
+		return "running"
+	case stopped:
+		return "stopped"
+	case suspended:
+		return "suspended"
+	}
+	return "unstarted"
+}
+
+// A named lap recorded by StopWatch.Lap
+type Lap struct {
+	Name string
+	// The time spent in this lap
+	Duration time.Duration
+	// The total time of the stopwatch when the lap was recorded
+	Elapsed time.Duration
+}
+
+// Times operations similar to Apache Commons StopWatch.  Time spent while
+// suspended is not counted.  A StopWatch is safe for use by multiple
+// goroutines.
+type StopWatch struct {
+	mu        sync.Mutex
+	name      string
+	clock     Clock
+	state     stopWatchState
+	start     time.Time
+	stop      time.Time
+	suspendAt time.Time
+	suspended time.Duration
+	split     *time.Duration
+	laps      []Lap
+}
+
+// StopWatchOption configures a StopWatch
+type StopWatchOption func(*StopWatch)
+
+// Names the stopwatch, the name is shown in the summary
+func WithName(name string) StopWatchOption {
+	return func(s *StopWatch) {
+		s.name = name
+	}
+}
+
+// Uses the clock provided instead of the system clock
+func WithClock(clock Clock) StopWatchOption {
+	return func(s *StopWatch) {
+		s.clock = clock
+	}
+}
+
+// Creates a stopwatch that has not been started
+func NewStopWatch(opts ...StopWatchOption) *StopWatch {
+	s := &StopWatch{clock: SystemClock}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Creates a stopwatch and starts it
+func StartStopWatch(opts ...StopWatchOption) *StopWatch {
+	s := NewStopWatch(opts...)
+	s.start = s.clock.Now()
+	s.state = running
+	return s
+}
+
+func illegalState(format string, args ...any) error {
+	return fmt.Errorf("%w: "+format, append([]any{ErrIllegalState}, args...)...)
+}
+
+// Returns the name of the stopwatch
+func (s *StopWatch) Name() string {
+	return s.name
+}
+
+// Starts timing.  A stopwatch can only be started once unless it is reset.
+func (s *StopWatch) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state != unstarted {
+		return illegalState("cannot start a stopwatch that is %s, reset it first", s.state)
+	}
+	s.start = s.clock.Now()
+	s.state = running
+	return nil
+}
+
+// Stops timing.  A suspended stopwatch may be stopped.
+func (s *StopWatch) Stop() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch s.state {
+	case running:
+		s.stop = s.clock.Now()
+	case suspended:
+		s.stop = s.suspendAt
+	default:
+		return illegalState("cannot stop a stopwatch that is %s", s.state)
+	}
+	s.state = stopped
+	return nil
+}
+
+// Resets the stopwatch so that it can be started again, clearing any split and laps
+func (s *StopWatch) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state = unstarted
+	s.start, s.stop, s.suspendAt = time.Time{}, time.Time{}, time.Time{}
+	s.suspended = 0
+	s.split = nil
+	s.laps = nil
+}
+
+// Records the current time as the split time while the stopwatch keeps running
+func (s *StopWatch) Split() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state != running {
+		return illegalState("cannot split a stopwatch that is %s", s.state)
+	}
+	split := s.elapsed()
+	s.split = &split
+	return nil
+}
+
+// Removes the split time
+func (s *StopWatch) Unsplit() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.split == nil {
+		return illegalState("cannot unsplit a stopwatch that has not been split")
+	}
+	s.split = nil
+	return nil
+}
+
+// Pauses timing until Resume is called
+func (s *StopWatch) Suspend() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state != running {
+		return illegalState("cannot suspend a stopwatch that is %s", s.state)
+	}
+	s.suspendAt = s.clock.Now()
+	s.state = suspended
+	return nil
+}
+
+// Continues timing after Suspend was called
+func (s *StopWatch) Resume() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state != suspended {
+		return illegalState("cannot resume a stopwatch that is %s", s.state)
+	}
+	s.suspended += s.clock.Now().Sub(s.suspendAt)
+	s.state = running
+	return nil
+}
+
+// Records a named lap covering the time since the previous lap, or since the
+// stopwatch was started for the first lap, and returns its duration
+func (s *StopWatch) Lap(name string) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state != running {
+		return 0, illegalState("cannot record a lap on a stopwatch that is %s", s.state)
+	}
+	elapsed := s.elapsed()
+	var previous time.Duration
+	if len(s.laps) > 0 {
+		previous = s.laps[len(s.laps)-1].Elapsed
+	}
+	lap := Lap{Name: name, Duration: elapsed - previous, Elapsed: elapsed}
+	s.laps = append(s.laps, lap)
+	return lap.Duration, nil
+}
+
+// Returns the laps recorded so far
+func (s *StopWatch) Laps() []Lap {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	laps := make([]Lap, len(s.laps))
+	copy(laps, s.laps)
+	return laps
+}
+
+// Returns the time elapsed, excluding time spent suspended
+func (s *StopWatch) Time() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.elapsed()
+}
+
+// Returns the time elapsed when Split was called
+func (s *StopWatch) SplitTime() (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.split == nil {
+		return 0, illegalState("cannot get the split time of a stopwatch that has not been split")
+	}
+	return *s.split, nil
+}
+
+// Returns the time the stopwatch was started
+func (s *StopWatch) StartTime() (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state == unstarted {
+		return time.Time{}, illegalState("cannot get the start time of a stopwatch that is %s", s.state)
+	}
+	return s.start, nil
+}
+
+// Returns whether the stopwatch is currently timing
+func (s *StopWatch) IsRunning() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state == running
+}
+
+// Returns whether the stopwatch is suspended
+func (s *StopWatch) IsSuspended() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state == suspended
+}
+
+// Returns whether the stopwatch has been stopped
+func (s *StopWatch) IsStopped() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state == stopped
+}
+
+func (s *StopWatch) elapsed() time.Duration {
+	switch s.state {
+	case running:
+		return s.clock.Now().Sub(s.start) - s.suspended
+	case suspended:
+		return s.suspendAt.Sub(s.start) - s.suspended
+	case stopped:
+		return s.stop.Sub(s.start) - s.suspended
+	}
+	return 0
+}
+
+// Returns the name and elapsed time, such as "batch: 1.5s"
+func (s *StopWatch) String() string {
+	if s.name == "" {
+		return s.Time().String()
+	}
+	return s.name + ": " + s.Time().String()
+}
+
+// Returns a table of the laps with the share of the total time spent in each
+//
+//	StopWatch 'batch': running time = 2s
+//	----------------------------------------
+//	Duration          %  Lap
+//	----------------------------------------
+//	500ms           25%  load
+//	1.5s            75%  transform
+func (s *StopWatch) Summary() string {
+	laps := s.Laps()
+	total := s.Time()
+
+	var b strings.Builder
+	if s.name == "" {
+		fmt.Fprintf(&b, "StopWatch: running time = %s\n", total)
+	} else {
+		fmt.Fprintf(&b, "StopWatch '%s': running time = %s\n", s.name, total)
+	}
+
+	if len(laps) == 0 {
+		b.WriteString("No laps recorded\n")
+		return b.String()
+	}
+
+	separator := strings.Repeat("-", 40) + "\n"
+	b.WriteString(separator)
+	fmt.Fprintf(&b, "%-14s %4s  %s\n", "Duration", "%", "Lap")
+	b.WriteString(separator)
+	for _, lap := range laps {
+		percent := 0.0
+		if total > 0 {
+			percent = float64(lap.Duration) / float64(total) * 100
+		}
+		fmt.Fprintf(&b, "%-14s %3.0f%%  %s\n", lap.Duration, percent, lap.Name)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,204 @@
+package timeutil
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var epoch = time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestStopWatchStartStop(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	watch := NewStopWatch(WithClock(clock))
+
+	assert.Equal(t, time.Duration(0), watch.Time())
+	assert.Nil(t, watch.Start())
+	assert.True(t, watch.IsRunning())
+
+	clock.Advance(2 * time.Second)
+	assert.Equal(t, 2*time.Second, watch.Time())
+
+	assert.Nil(t, watch.Stop())
+	assert.True(t, watch.IsStopped())
+	clock.Advance(time.Second)
+	assert.Equal(t, 2*time.Second, watch.Time())
+
+	start, err := watch.StartTime()
+	assert.Nil(t, err)
+	assert.Equal(t, epoch, start)
+}
+
+func TestStopWatchSuspendResume(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	watch := StartStopWatch(WithClock(clock))
+
+	clock.Advance(time.Second)
+	assert.Nil(t, watch.Suspend())
+	assert.True(t, watch.IsSuspended())
+	clock.Advance(time.Minute)
+	assert.Equal(t, time.Second, watch.Time())
+
+	assert.Nil(t, watch.Resume())
+	clock.Advance(time.Second)
+	assert.Equal(t, 2*time.Second, watch.Time())
+
+	assert.Nil(t, watch.Suspend())
+	clock.Advance(time.Minute)
+	assert.Nil(t, watch.Stop())
+	assert.Equal(t, 2*time.Second, watch.Time())
+}
+
+func TestStopWatchSplit(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	watch := StartStopWatch(WithClock(clock))
+
+	_, err := watch.SplitTime()
+	assert.ErrorIs(t, err, ErrIllegalState)
+
+	clock.Advance(time.Second)
+	assert.Nil(t, watch.Split())
+	clock.Advance(time.Second)
+
+	split, err := watch.SplitTime()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Second, split)
+	assert.Equal(t, 2*time.Second, watch.Time())
+
+	assert.Nil(t, watch.Unsplit())
+	assert.ErrorIs(t, watch.Unsplit(), ErrIllegalState)
+	_, err = watch.SplitTime()
+	assert.ErrorIs(t, err, ErrIllegalState)
+}
+
+func TestStopWatchIllegalTransitions(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	watch := NewStopWatch(WithClock(clock))
+
+	var tests = map[string]struct {
+		setup    func()
+		action   func() error
+		expected string
+	}{
+		"stop before start":    {action: watch.Stop, expected: "illegal stopwatch state: cannot stop a stopwatch that is unstarted"},
+		"split before start":   {action: watch.Split, expected: "illegal stopwatch state: cannot split a stopwatch that is unstarted"},
+		"suspend before start": {action: watch.Suspend, expected: "illegal stopwatch state: cannot suspend a stopwatch that is unstarted"},
+		"resume while running": {
+			setup:    func() { _ = watch.Start() },
+			action:   watch.Resume,
+			expected: "illegal stopwatch state: cannot resume a stopwatch that is running",
+		},
+		"start twice": {
+			setup:    func() { _ = watch.Start() },
+			action:   watch.Start,
+			expected: "illegal stopwatch state: cannot start a stopwatch that is running, reset it first",
+		},
+		"start after stop": {
+			setup:    func() { _ = watch.Start(); _ = watch.Stop() },
+			action:   watch.Start,
+			expected: "illegal stopwatch state: cannot start a stopwatch that is stopped, reset it first",
+		},
+		"lap while suspended": {
+			setup: func() { _ = watch.Start(); _ = watch.Suspend() },
+			action: func() error {
+				_, err := watch.Lap("x")
+				return err
+			},
+			expected: "illegal stopwatch state: cannot record a lap on a stopwatch that is suspended",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			watch.Reset()
+			if test.setup != nil {
+				test.setup()
+			}
+			err := test.action()
+			assert.True(t, errors.Is(err, ErrIllegalState))
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestStopWatchReset(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	watch := StartStopWatch(WithClock(clock))
+	clock.Advance(time.Second)
+	_, _ = watch.Lap("first")
+	_ = watch.Split()
+
+	watch.Reset()
+	assert.Equal(t, time.Duration(0), watch.Time())
+	assert.Empty(t, watch.Laps())
+	_, err := watch.StartTime()
+	assert.ErrorIs(t, err, ErrIllegalState)
+	assert.Nil(t, watch.Start())
+}
+
+func TestStopWatchLaps(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	watch := StartStopWatch(WithClock(clock), WithName("batch"))
+
+	clock.Advance(500 * time.Millisecond)
+	lap, err := watch.Lap("load")
+	assert.Nil(t, err)
+	assert.Equal(t, 500*time.Millisecond, lap)
+
+	clock.Advance(time.Second)
+	_ = watch.Suspend()
+	clock.Advance(time.Hour)
+	_ = watch.Resume()
+	clock.Advance(500 * time.Millisecond)
+	lap, _ = watch.Lap("transform")
+	assert.Equal(t, 1500*time.Millisecond, lap)
+	_ = watch.Stop()
+
+	assert.Equal(t, []Lap{
+		{Name: "load", Duration: 500 * time.Millisecond, Elapsed: 500 * time.Millisecond},
+		{Name: "transform", Duration: 1500 * time.Millisecond, Elapsed: 2 * time.Second},
+	}, watch.Laps())
+
+	assert.Equal(t, "batch: 2s", watch.String())
+	assert.Equal(t, ""+
+		"StopWatch 'batch': running time = 2s\n"+
+		"----------------------------------------\n"+
+		"Duration          %  Lap\n"+
+		"----------------------------------------\n"+
+		"500ms           25%  load\n"+
+		"1.5s            75%  transform\n", watch.Summary())
+}
+
+func TestStopWatchSummaryWithoutLaps(t *testing.T) {
+	watch := NewStopWatch(WithClock(NewFakeClock(epoch)))
+	assert.Equal(t, "StopWatch: running time = 0s\nNo laps recorded\n", watch.Summary())
+	assert.Equal(t, "0s", watch.String())
+}
+
+func TestStopWatchConcurrentLaps(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	watch := StartStopWatch(WithClock(clock))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			clock.Advance(time.Millisecond)
+			_, _ = watch.Lap("worker")
+			_ = watch.Time()
+		}()
+	}
+	wg.Wait()
+
+	laps := watch.Laps()
+	assert.Len(t, laps, 50)
+	var total time.Duration
+	for _, lap := range laps {
+		total += lap.Duration
+	}
+	assert.Equal(t, laps[len(laps)-1].Elapsed, total)
+}
@@ -0,0 +1,179 @@
+package tuple
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jwmajors81/golang-commons-lang/sorted"
+	"golang.org/x/exp/constraints"
+)
+
+// An immutable pair of values.  Pairs of comparable types can be compared
+// with == and used as map keys.
+//
+// A pair is marshaled to JSON as a two element array; use Object to marshal
+// it as an object with left and right keys.  Both forms can be unmarshaled.
+type Pair[L, R any] struct {
+	left  L
+	right R
+}
+
+// Creates a pair of the values
+func PairOf[L, R any](left L, right R) Pair[L, R] {
+	return Pair[L, R]{left: left, right: right}
+}
+
+// Returns the left value
+func (p Pair[L, R]) Left() L {
+	return p.left
+}
+
+// Returns the right value
+func (p Pair[L, R]) Right() R {
+	return p.right
+}
+
+// Returns both values so that they can be assigned in one statement
+func (p Pair[L, R]) Values() (L, R) {
+	return p.left, p.right
+}
+
+// Returns a copy of the pair with the left value replaced
+func (p Pair[L, R]) WithLeft(left L) Pair[L, R] {
+	p.left = left
+	return p
+}
+
+// Returns a copy of the pair with the right value replaced
+func (p Pair[L, R]) WithRight(right R) Pair[L, R] {
+	p.right = right
+	return p
+}
+
+// Returns a pair with the left and right values exchanged
+func (p Pair[L, R]) Swap() Pair[R, L] {
+	return Pair[R, L]{left: p.right, right: p.left}
+}
+
+// Returns the pair formatted as "(left, right)"
+func (p Pair[L, R]) String() string {
+	return p.Sprintf("(%[1]v, %[2]v)")
+}
+
+// Formats the pair with fmt.Sprintf, passing the left and right values as
+// the first and second arguments, such as p.Sprintf("%[1]v=%[2]v")
+func (p Pair[L, R]) Sprintf(format string) string {
+	return fmt.Sprintf(format, p.left, p.right)
+}
+
+// Returns the pair as a struct whose fields are exported, which is marshaled
+// to JSON as an object with left and right keys
+func (p Pair[L, R]) Object() PairObject[L, R] {
+	return PairObject[L, R]{Left: p.left, Right: p.right}
+}
+
+// Marshals the pair as a JSON array of the left and right values
+func (p Pair[L, R]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{p.left, p.right})
+}
+
+// Unmarshals a JSON array of two values, or an object with left and right
+// keys
+func (p *Pair[L, R]) UnmarshalJSON(data []byte) error {
+	switch firstByte(data) {
+	case 'n':
+		return nil
+	case '{':
+		var object PairObject[L, R]
+		if err := json.Unmarshal(data, &object); err != nil {
+			return err
+		}
+		*p = object.Pair()
+		return nil
+	}
+
+	elements, err := unmarshalArray(data, 2, "pair")
+	if err != nil {
+		return err
+	}
+	var result Pair[L, R]
+	if err := json.Unmarshal(elements[0], &result.left); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(elements[1], &result.right); err != nil {
+		return err
+	}
+	*p = result
+	return nil
+}
+
+// Returns the first byte of the JSON value
+func firstByte(data []byte) byte {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return 0
+	}
+	return data[0]
+}
+
+// Splits a JSON array that must contain exactly size elements
+func unmarshalArray(data []byte, size int, name string) ([]json.RawMessage, error) {
+	var elements []json.RawMessage
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal %s into a %s: expected an array or object", bytes.TrimSpace(data), name)
+	}
+	if len(elements) != size {
+		return nil, fmt.Errorf("cannot unmarshal an array of %d elements into a %s: expected %d", len(elements), name, size)
+	}
+	return elements, nil
+}
+
+// A pair with exported fields, which encoding/json marshals as an object
+type PairObject[L, R any] struct {
+	Left  L `json:"left"`
+	Right R `json:"right"`
+}
+
+// Returns the values as a Pair
+func (o PairObject[L, R]) Pair() Pair[L, R] {
+	return PairOf(o.Left, o.Right)
+}
+
+// Returns a comparator that orders pairs by their left values and then by
+// their right values
+func PairComparator[L, R any](left sorted.Comparator[L], right sorted.Comparator[R]) sorted.Comparator[Pair[L, R]] {
+	return sorted.ComparingWith(Pair[L, R].Left, left).
+		ThenComparing(sorted.ComparingWith(Pair[L, R].Right, right))
+}
+
+// Compares pairs of ordered values by their left values and then by their
+// right values, returning a negative number, zero or a positive number
+func ComparePairs[L, R constraints.Ordered](a, b Pair[L, R]) int {
+	return PairComparator(sorted.NaturalOrder[L](), sorted.NaturalOrder[R]())(a, b)
+}
+
+// Pairs the elements of the slices by index.  The result is as long as the
+// shorter slice; the extra elements of the longer slice are ignored.
+func Zip[L, R any](lefts []L, rights []R) []Pair[L, R] {
+	size := len(lefts)
+	if len(rights) < size {
+		size = len(rights)
+	}
+	result := make([]Pair[L, R], size)
+	for i := range result {
+		result[i] = PairOf(lefts[i], rights[i])
+	}
+	return result
+}
+
+// Splits the pairs into a slice of their left values and a slice of their
+// right values
+func Unzip[L, R any](pairs []Pair[L, R]) ([]L, []R) {
+	lefts := make([]L, len(pairs))
+	rights := make([]R, len(pairs))
+	for i, pair := range pairs {
+		lefts[i], rights[i] = pair.Values()
+	}
+	return lefts, rights
+}
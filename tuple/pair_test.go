@@ -0,0 +1,117 @@
+package tuple
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/jwmajors81/golang-commons-lang/sorted"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPair(t *testing.T) {
+	pair := PairOf("port", 8080)
+	assert.Equal(t, "port", pair.Left())
+	assert.Equal(t, 8080, pair.Right())
+
+	key, value := pair.Values()
+	assert.Equal(t, "port", key)
+	assert.Equal(t, 8080, value)
+
+	assert.Equal(t, PairOf(8080, "port"), pair.Swap())
+	assert.Equal(t, PairOf("host", 8080), pair.WithLeft("host"))
+	assert.Equal(t, PairOf("port", 9090), pair.WithRight(9090))
+	assert.Equal(t, PairOf("port", 8080), pair, "the original pair is unchanged")
+}
+
+func TestPairIsComparable(t *testing.T) {
+	counts := map[Pair[string, int]]int{}
+	counts[PairOf("a", 1)]++
+	counts[PairOf("a", 1)]++
+	counts[PairOf("a", 2)]++
+	assert.Equal(t, 2, counts[PairOf("a", 1)])
+	assert.True(t, PairOf("a", 1) == PairOf("a", 1))
+	assert.False(t, PairOf("a", 1) == PairOf("b", 1))
+}
+
+func TestPairString(t *testing.T) {
+	assert.Equal(t, "(port, 8080)", PairOf("port", 8080).String())
+	assert.Equal(t, "(<nil>, [1 2])", PairOf[error, []int](nil, []int{1, 2}).String())
+	assert.Equal(t, "port=8080", PairOf("port", 8080).Sprintf("%[1]v=%[2]v"))
+	assert.Equal(t, "8080 is the port", PairOf("port", 8080).Sprintf("%[2]d is the %[1]s"))
+}
+
+func TestComparePairs(t *testing.T) {
+	var tests = map[string]struct {
+		a, b     Pair[string, float64]
+		expected int
+	}{
+		"equal":           {a: PairOf("a", 1.0), b: PairOf("a", 1.0), expected: 0},
+		"left differs":    {a: PairOf("a", 9.0), b: PairOf("b", 1.0), expected: -1},
+		"right differs":   {a: PairOf("a", 2.0), b: PairOf("a", 1.0), expected: 1},
+		"NaN sorts first": {a: PairOf("a", math.NaN()), b: PairOf("a", 1.0), expected: -1},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ComparePairs(test.a, test.b))
+			assert.Equal(t, -test.expected, ComparePairs(test.b, test.a))
+		})
+	}
+}
+
+func TestPairComparator(t *testing.T) {
+	pairs := []Pair[string, int]{PairOf("b", 1), PairOf("a", 1), PairOf("B", 0), PairOf("a", 2)}
+	PairComparator(sorted.CaseInsensitive(), sorted.NaturalOrder[int]().Reversed()).Sort(pairs)
+	assert.Equal(t, []Pair[string, int]{PairOf("a", 2), PairOf("a", 1), PairOf("B", 0), PairOf("b", 1)}, pairs)
+}
+
+func TestPairJSON(t *testing.T) {
+	data, err := json.Marshal(PairOf("port", 8080))
+	assert.Nil(t, err)
+	assert.Equal(t, `["port",8080]`, string(data))
+
+	data, err = json.Marshal(PairOf("port", 8080).Object())
+	assert.Nil(t, err)
+	assert.Equal(t, `{"left":"port","right":8080}`, string(data))
+
+	var fromArray Pair[string, int]
+	assert.Nil(t, json.Unmarshal([]byte(` ["host", 443] `), &fromArray))
+	assert.Equal(t, PairOf("host", 443), fromArray)
+
+	var fromObject Pair[string, int]
+	assert.Nil(t, json.Unmarshal([]byte(`{"right": 443, "left": "host"}`), &fromObject))
+	assert.Equal(t, PairOf("host", 443), fromObject)
+
+	nested := map[string]Pair[int, []string]{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"a":[1,["x","y"]],"b":null}`), &nested))
+	assert.Equal(t, map[string]Pair[int, []string]{"a": PairOf(1, []string{"x", "y"}), "b": {}}, nested)
+}
+
+func TestPairJSONErrors(t *testing.T) {
+	var pair Pair[string, int]
+	assert.EqualError(t, json.Unmarshal([]byte(`["a", 1, 2]`), &pair), "cannot unmarshal an array of 3 elements into a pair: expected 2")
+	assert.EqualError(t, json.Unmarshal([]byte(`"a"`), &pair), `cannot unmarshal "a" into a pair: expected an array or object`)
+	assert.Error(t, json.Unmarshal([]byte(`[1, 1]`), &pair))
+	assert.Error(t, json.Unmarshal([]byte(`{"left": 1}`), &pair))
+	assert.Equal(t, Pair[string, int]{}, pair, "a failed unmarshal leaves the pair unchanged")
+}
+
+func TestZip(t *testing.T) {
+	assert.Equal(t, []Pair[string, int]{PairOf("a", 1), PairOf("b", 2)}, Zip([]string{"a", "b", "c"}, []int{1, 2}))
+	assert.Equal(t, []Pair[string, int]{PairOf("a", 1)}, Zip([]string{"a"}, []int{1, 2}))
+	assert.Equal(t, []Pair[string, int]{}, Zip[string, int](nil, []int{1}))
+}
+
+func TestUnzip(t *testing.T) {
+	lefts, rights := Unzip([]Pair[string, int]{PairOf("a", 1), PairOf("b", 2)})
+	assert.Equal(t, []string{"a", "b"}, lefts)
+	assert.Equal(t, []int{1, 2}, rights)
+
+	lefts, rights = Unzip[string, int](nil)
+	assert.Empty(t, lefts)
+	assert.Empty(t, rights)
+
+	original := Zip([]string{"x", "y"}, []bool{true, false})
+	assert.Equal(t, original, Zip(Unzip(original)))
+}
@@ -0,0 +1,150 @@
+package tuple
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jwmajors81/golang-commons-lang/sorted"
+	"golang.org/x/exp/constraints"
+)
+
+// An immutable triple of values.  Triples of comparable types can be
+// compared with == and used as map keys.
+//
+// A triple is marshaled to JSON as a three element array; use Object to
+// marshal it as an object with left, middle and right keys.  Both forms can
+// be unmarshaled.
+type Triple[L, M, R any] struct {
+	left   L
+	middle M
+	right  R
+}
+
+// Creates a triple of the values
+func TripleOf[L, M, R any](left L, middle M, right R) Triple[L, M, R] {
+	return Triple[L, M, R]{left: left, middle: middle, right: right}
+}
+
+// Returns the left value
+func (t Triple[L, M, R]) Left() L {
+	return t.left
+}
+
+// Returns the middle value
+func (t Triple[L, M, R]) Middle() M {
+	return t.middle
+}
+
+// Returns the right value
+func (t Triple[L, M, R]) Right() R {
+	return t.right
+}
+
+// Returns the three values so that they can be assigned in one statement
+func (t Triple[L, M, R]) Values() (L, M, R) {
+	return t.left, t.middle, t.right
+}
+
+// Returns a copy of the triple with the left value replaced
+func (t Triple[L, M, R]) WithLeft(left L) Triple[L, M, R] {
+	t.left = left
+	return t
+}
+
+// Returns a copy of the triple with the middle value replaced
+func (t Triple[L, M, R]) WithMiddle(middle M) Triple[L, M, R] {
+	t.middle = middle
+	return t
+}
+
+// Returns a copy of the triple with the right value replaced
+func (t Triple[L, M, R]) WithRight(right R) Triple[L, M, R] {
+	t.right = right
+	return t
+}
+
+// Returns a triple with the left and right values exchanged
+func (t Triple[L, M, R]) Swap() Triple[R, M, L] {
+	return Triple[R, M, L]{left: t.right, middle: t.middle, right: t.left}
+}
+
+// Returns the triple formatted as "(left, middle, right)"
+func (t Triple[L, M, R]) String() string {
+	return t.Sprintf("(%[1]v, %[2]v, %[3]v)")
+}
+
+// Formats the triple with fmt.Sprintf, passing the left, middle and right
+// values as the first, second and third arguments
+func (t Triple[L, M, R]) Sprintf(format string) string {
+	return fmt.Sprintf(format, t.left, t.middle, t.right)
+}
+
+// Returns the triple as a struct whose fields are exported, which is
+// marshaled to JSON as an object with left, middle and right keys
+func (t Triple[L, M, R]) Object() TripleObject[L, M, R] {
+	return TripleObject[L, M, R]{Left: t.left, Middle: t.middle, Right: t.right}
+}
+
+// Marshals the triple as a JSON array of the left, middle and right values
+func (t Triple[L, M, R]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{t.left, t.middle, t.right})
+}
+
+// Unmarshals a JSON array of three values, or an object with left, middle
+// and right keys
+func (t *Triple[L, M, R]) UnmarshalJSON(data []byte) error {
+	switch firstByte(data) {
+	case 'n':
+		return nil
+	case '{':
+		var object TripleObject[L, M, R]
+		if err := json.Unmarshal(data, &object); err != nil {
+			return err
+		}
+		*t = object.Triple()
+		return nil
+	}
+
+	elements, err := unmarshalArray(data, 3, "triple")
+	if err != nil {
+		return err
+	}
+	var result Triple[L, M, R]
+	if err := json.Unmarshal(elements[0], &result.left); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(elements[1], &result.middle); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(elements[2], &result.right); err != nil {
+		return err
+	}
+	*t = result
+	return nil
+}
+
+// A triple with exported fields, which encoding/json marshals as an object
+type TripleObject[L, M, R any] struct {
+	Left   L `json:"left"`
+	Middle M `json:"middle"`
+	Right  R `json:"right"`
+}
+
+// Returns the values as a Triple
+func (o TripleObject[L, M, R]) Triple() Triple[L, M, R] {
+	return TripleOf(o.Left, o.Middle, o.Right)
+}
+
+// Returns a comparator that orders triples by their left values, then by
+// their middle values and then by their right values
+func TripleComparator[L, M, R any](left sorted.Comparator[L], middle sorted.Comparator[M], right sorted.Comparator[R]) sorted.Comparator[Triple[L, M, R]] {
+	return sorted.ComparingWith(Triple[L, M, R].Left, left).
+		ThenComparing(sorted.ComparingWith(Triple[L, M, R].Middle, middle)).
+		ThenComparing(sorted.ComparingWith(Triple[L, M, R].Right, right))
+}
+
+// Compares triples of ordered values by their left, middle and then right
+// values, returning a negative number, zero or a positive number
+func CompareTriples[L, M, R constraints.Ordered](a, b Triple[L, M, R]) int {
+	return TripleComparator(sorted.NaturalOrder[L](), sorted.NaturalOrder[M](), sorted.NaturalOrder[R]())(a, b)
+}
@@ -0,0 +1,81 @@
+package tuple
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jwmajors81/golang-commons-lang/sorted"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTriple(t *testing.T) {
+	triple := TripleOf("x", 1, true)
+	assert.Equal(t, "x", triple.Left())
+	assert.Equal(t, 1, triple.Middle())
+	assert.Equal(t, true, triple.Right())
+
+	left, middle, right := triple.Values()
+	assert.Equal(t, "x", left)
+	assert.Equal(t, 1, middle)
+	assert.Equal(t, true, right)
+
+	assert.Equal(t, TripleOf(true, 1, "x"), triple.Swap())
+	assert.Equal(t, TripleOf("y", 1, true), triple.WithLeft("y"))
+	assert.Equal(t, TripleOf("x", 2, true), triple.WithMiddle(2))
+	assert.Equal(t, TripleOf("x", 1, false), triple.WithRight(false))
+	assert.Equal(t, TripleOf("x", 1, true), triple, "the original triple is unchanged")
+	assert.True(t, TripleOf("x", 1, true) == triple)
+}
+
+func TestTripleString(t *testing.T) {
+	assert.Equal(t, "(x, 1, true)", TripleOf("x", 1, true).String())
+	assert.Equal(t, "x:1:true", TripleOf("x", 1, true).Sprintf("%v:%v:%v"))
+	assert.Equal(t, "true/x", TripleOf("x", 1, true).Sprintf("%[3]v/%[1]v"))
+}
+
+func TestCompareTriples(t *testing.T) {
+	var tests = map[string]struct {
+		a, b     Triple[int, string, int]
+		expected int
+	}{
+		"equal":          {a: TripleOf(1, "a", 1), b: TripleOf(1, "a", 1), expected: 0},
+		"left differs":   {a: TripleOf(1, "z", 9), b: TripleOf(2, "a", 1), expected: -1},
+		"middle differs": {a: TripleOf(1, "b", 1), b: TripleOf(1, "a", 9), expected: 1},
+		"right differs":  {a: TripleOf(1, "a", 1), b: TripleOf(1, "a", 2), expected: -1},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, CompareTriples(test.a, test.b))
+			assert.Equal(t, -test.expected, CompareTriples(test.b, test.a))
+		})
+	}
+}
+
+func TestTripleComparator(t *testing.T) {
+	triples := []Triple[int, string, int]{TripleOf(1, "b", 1), TripleOf(1, "a", 1), TripleOf(0, "z", 1), TripleOf(1, "a", 2)}
+	TripleComparator(sorted.NaturalOrder[int](), sorted.NaturalOrder[string](), sorted.NaturalOrder[int]().Reversed()).Sort(triples)
+	assert.Equal(t, []Triple[int, string, int]{TripleOf(0, "z", 1), TripleOf(1, "a", 2), TripleOf(1, "a", 1), TripleOf(1, "b", 1)}, triples)
+}
+
+func TestTripleJSON(t *testing.T) {
+	data, err := json.Marshal(TripleOf("x", 1, true))
+	assert.Nil(t, err)
+	assert.Equal(t, `["x",1,true]`, string(data))
+
+	data, err = json.Marshal(TripleOf("x", 1, true).Object())
+	assert.Nil(t, err)
+	assert.Equal(t, `{"left":"x","middle":1,"right":true}`, string(data))
+
+	var fromArray Triple[string, int, bool]
+	assert.Nil(t, json.Unmarshal([]byte(`["y", 2, true]`), &fromArray))
+	assert.Equal(t, TripleOf("y", 2, true), fromArray)
+
+	var fromObject Triple[string, int, bool]
+	assert.Nil(t, json.Unmarshal([]byte(`{"left": "y", "middle": 2}`), &fromObject))
+	assert.Equal(t, TripleOf("y", 2, false), fromObject)
+
+	var triple Triple[string, int, bool]
+	assert.EqualError(t, json.Unmarshal([]byte(`["y", 2]`), &triple), "cannot unmarshal an array of 2 elements into a triple: expected 3")
+	assert.Error(t, json.Unmarshal([]byte(`["y", 2, "no"]`), &triple))
+}
@@ -0,0 +1,296 @@
+package structs
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jwmajors81/golang-commons-lang/sorted"
+	utils "github.com/jwmajors81/golang-commons-lang/strings"
+	"github.com/jwmajors81/golang-commons-lang/validate"
+)
+
+const (
+	lowerLetters = "abcdefghijklmnopqrstuvwxyz"
+	upperLetters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digits       = "0123456789"
+)
+
+var builtinRules = map[string]Rule{
+	"required": required,
+	"min":      bound("at least", func(value, limit float64) bool { return value >= limit }),
+	"max":      bound("at most", func(value, limit float64) bool { return value <= limit }),
+	"len":      length,
+	"oneof":    oneOf,
+	"pattern":  pattern,
+	"alpha":    onlyChars("letters", lowerLetters+upperLetters),
+	"numeric":  onlyChars("digits", digits),
+	"alphanum": onlyChars("letters and digits", lowerLetters+upperLetters+digits),
+	"chars":    chars,
+	"excludes": excludes,
+	"eqfield":  crossField("equal", func(c int) bool { return c == 0 }),
+	"nefield":  crossField("not equal", func(c int) bool { return c != 0 }),
+	"gtfield":  crossField("be greater than", func(c int) bool { return c > 0 }),
+	"gtefield": crossField("be greater than or equal to", func(c int) bool { return c >= 0 }),
+	"ltfield":  crossField("be less than", func(c int) bool { return c < 0 }),
+	"ltefield": crossField("be less than or equal to", func(c int) bool { return c <= 0 }),
+}
+
+func failure(sentinel error, format string, args ...any) error {
+	return &validate.Error{Message: fmt.Sprintf(format, args...), Err: sentinel}
+}
+
+func invalidTag(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidTag, fmt.Sprintf(format, args...))
+}
+
+func required(field Field) error {
+	value := field.Value
+	if value.Kind() == reflect.Interface && !value.IsNil() {
+		value = value.Elem()
+	}
+	if isEmpty(value) {
+		return failure(ErrRequired, "is required")
+	}
+	return nil
+}
+
+// Returns the number a min or max rule compares and how to describe it
+func measure(value reflect.Value) (float64, string, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), "", true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(value.Uint()), "", true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), "", true
+	case reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), " characters long", true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(value.Len()), " elements", true
+	}
+	return 0, "", false
+}
+
+// Returns the value a rule checks, following a pointer to its element.  The
+// result is false for a nil pointer, which has no value to check and is left
+// to the required rule.
+func ruleValue(field Field) (reflect.Value, bool) {
+	value := field.Value
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return value, false
+		}
+		value = value.Elem()
+	}
+	return value, true
+}
+
+func bound(description string, ok func(value, limit float64) bool) Rule {
+	return func(field Field) error {
+		limit, err := strconv.ParseFloat(field.Param, 64)
+		if err != nil {
+			return invalidTag("%q is not a number", field.Param)
+		}
+		value, present := ruleValue(field)
+		if !present {
+			return nil
+		}
+		measured, unit, supported := measure(value)
+		if !supported {
+			return invalidTag("cannot compare the size of %s", value.Type())
+		}
+		if ok(measured, limit) {
+			return nil
+		}
+		if unit == " elements" {
+			return failure(validate.ErrNotInRange, "must contain %s %s%s", description, field.Param, unit)
+		}
+		return failure(validate.ErrNotInRange, "must be %s %s%s", description, field.Param, unit)
+	}
+}
+
+func length(field Field) error {
+	expected, err := strconv.Atoi(field.Param)
+	if err != nil {
+		return invalidTag("%q is not an integer", field.Param)
+	}
+	value, present := ruleValue(field)
+	if !present {
+		return nil
+	}
+	measured, unit, supported := measure(value)
+	if !supported || unit == "" {
+		return invalidTag("len cannot be used with %s", value.Type())
+	}
+	if int(measured) == expected {
+		return nil
+	}
+	if unit == " elements" {
+		return failure(validate.ErrNotInRange, "must contain exactly %d%s", expected, unit)
+	}
+	return failure(validate.ErrNotInRange, "must be exactly %d%s", expected, unit)
+}
+
+func oneOf(field Field) error {
+	rv, present := ruleValue(field)
+	if !present {
+		return nil
+	}
+	value, ok := text(rv)
+	if !ok {
+		return invalidTag("oneof cannot be used with %s", rv.Type())
+	}
+	words := strings.Fields(field.Param)
+	for _, word := range words {
+		if value == word {
+			return nil
+		}
+	}
+	return failure(validate.ErrIllegalArgument, "must be one of %s", strings.Join(words, ", "))
+}
+
+// Returns the text of strings, or the decimal form of integers, for oneof
+func text(value reflect.Value) (string, bool) {
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), true
+	}
+	return "", false
+}
+
+var patterns sync.Map
+
+func pattern(field Field) error {
+	value, present := ruleValue(field)
+	if !present {
+		return nil
+	}
+	if value.Kind() != reflect.String {
+		return invalidTag("pattern cannot be used with %s", value.Type())
+	}
+
+	var re *regexp.Regexp
+	if cached, ok := patterns.Load(field.Param); ok {
+		re = cached.(*regexp.Regexp)
+	} else {
+		compiled, err := regexp.Compile(`^(?:` + field.Param + `)$`)
+		if err != nil {
+			return invalidTag("%q is not a valid regular expression", field.Param)
+		}
+		patterns.Store(field.Param, compiled)
+		re = compiled
+	}
+
+	if re.MatchString(value.String()) {
+		return nil
+	}
+	return failure(validate.ErrPatternMismatch, "must match the pattern %s", field.Param)
+}
+
+// Splits the characters of a string so they can be passed to ContainsOnly and ContainsNone
+func characters(set string) []string {
+	var result []string
+	for _, r := range set {
+		result = append(result, string(r))
+	}
+	return result
+}
+
+func onlyChars(description string, set string) Rule {
+	allowed := characters(set)
+	return func(field Field) error {
+		value, present := ruleValue(field)
+		if !present {
+			return nil
+		}
+		if value.Kind() != reflect.String {
+			return invalidTag("cannot check the characters of %s", value.Type())
+		}
+		if utils.ContainsOnly(value.String(), allowed...) {
+			return nil
+		}
+		return failure(validate.ErrPatternMismatch, "must contain only %s", description)
+	}
+}
+
+func chars(field Field) error {
+	value, present := ruleValue(field)
+	if !present {
+		return nil
+	}
+	if value.Kind() != reflect.String {
+		return invalidTag("chars cannot be used with %s", value.Type())
+	}
+	if utils.ContainsOnly(value.String(), characters(field.Param)...) {
+		return nil
+	}
+	return failure(validate.ErrPatternMismatch, "must contain only the characters %s", field.Param)
+}
+
+func excludes(field Field) error {
+	value, present := ruleValue(field)
+	if !present {
+		return nil
+	}
+	if value.Kind() != reflect.String {
+		return invalidTag("excludes cannot be used with %s", value.Type())
+	}
+	if utils.ContainsNone(value.String(), characters(field.Param)...) {
+		return nil
+	}
+	return failure(validate.ErrPatternMismatch, "must not contain any of the characters %s", field.Param)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func crossField(description string, ok func(comparison int) bool) Rule {
+	return func(field Field) error {
+		if field.Parent.Kind() != reflect.Struct {
+			return invalidTag("the field %s cannot be found", field.Param)
+		}
+		other, found := field.Parent.Type().FieldByName(field.Param)
+		if !found || !other.IsExported() {
+			return invalidTag("the field %s cannot be found in %s", field.Param, field.Parent.Type())
+		}
+
+		left := field.Value
+		right, err := field.Parent.FieldByIndexErr(other.Index)
+		if err != nil {
+			// the field is promoted through a nil embedded pointer, so there
+			// is no value to compare with
+			return failure(validate.ErrIllegalArgument, "must %s %s", description, jsonName(other))
+		}
+		if left.Type() != right.Type() || !orderable(left.Type()) {
+			return invalidTag("cannot compare %s with the field %s of type %s", left.Type(), field.Param, right.Type())
+		}
+
+		comparison := sorted.NewCompareToBuilder().Append(left.Interface(), right.Interface()).Result()
+		if ok(comparison) {
+			return nil
+		}
+		return failure(validate.ErrIllegalArgument, "must %s %s", description, jsonName(other))
+	}
+}
+
+func orderable(t reflect.Type) bool {
+	if t == timeType {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
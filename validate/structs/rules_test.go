@@ -0,0 +1,199 @@
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRules(t *testing.T) {
+	name := "bob"
+	var noName *string
+
+	var tests = map[string]struct {
+		value    any
+		expected string
+	}{
+		"required string": {value: struct {
+			A string `validate:"required"`
+		}{}, expected: "A: is required"},
+		"required empty slice": {value: struct {
+			A []int `validate:"required"`
+		}{A: []int{}}, expected: "A: is required"},
+		"required nil pointer": {value: struct {
+			A *string `validate:"required"`
+		}{A: noName}, expected: "A: is required"},
+		"required pointer": {value: struct {
+			A *string `validate:"required"`
+		}{A: &name}},
+		"required zero int": {value: struct {
+			A int `validate:"required"`
+		}{}, expected: "A: is required"},
+		"required time": {value: struct {
+			A time.Time `validate:"required"`
+		}{}, expected: "A: is required"},
+		"required nil interface": {value: struct {
+			A any `validate:"required"`
+		}{}, expected: "A: is required"},
+		"optional pointer": {value: struct {
+			A *string `validate:"omitempty,alpha,len=3,oneof=bob ann,chars=bo,excludes=<>,pattern=[a-z]+"`
+		}{A: &name}},
+		"nil pointer": {value: struct {
+			A *string `validate:"alpha,len=3,oneof=ann,chars=x,excludes=b,pattern=[0-9]+"`
+		}{A: noName}},
+		"pointer numeric": {value: struct {
+			A *string `validate:"omitempty,numeric"`
+		}{A: &name}, expected: "A: must contain only digits"},
+		"pointer len": {value: struct {
+			A *string `validate:"len=2"`
+		}{A: &name}, expected: "A: must be exactly 2 characters long"},
+		"pointer oneof": {value: struct {
+			A *string `validate:"oneof=ann"`
+		}{A: &name}, expected: "A: must be one of ann"},
+		"pointer pattern": {value: struct {
+			A *string `validate:"pattern=[0-9]+"`
+		}{A: &name}, expected: "A: must match the pattern [0-9]+"},
+		"pointer chars": {value: struct {
+			A *string `validate:"chars=b"`
+		}{A: &name}, expected: "A: must contain only the characters b"},
+		"pointer excludes": {value: struct {
+			A *string `validate:"excludes=o"`
+		}{A: &name}, expected: "A: must not contain any of the characters o"},
+		"min runes": {value: struct {
+			A string `validate:"min=3"`
+		}{A: "äöü"}},
+		"min float": {value: struct {
+			A float64 `validate:"min=0.5"`
+		}{A: 0.25}, expected: "A: must be at least 0.5"},
+		"max slice": {value: struct {
+			A []int `validate:"max=1"`
+		}{A: []int{1, 2}}, expected: "A: must contain at most 1 elements"},
+		"min pointer": {value: struct {
+			A *string `validate:"min=4"`
+		}{A: &name}, expected: "A: must be at least 4 characters long"},
+		"min nil pointer": {value: struct {
+			A *string `validate:"min=4"`
+		}{}},
+		"max uint": {value: struct {
+			A uint8 `validate:"max=10"`
+		}{A: 11}, expected: "A: must be at most 10"},
+		"len map": {value: struct {
+			A map[string]int `validate:"len=1"`
+		}{}, expected: "A: must contain exactly 1 elements"},
+		"oneof int": {value: struct {
+			A int `validate:"oneof=1 2 3"`
+		}{A: 2}},
+		"oneof fails": {value: struct {
+			A int `validate:"oneof=1 2 3"`
+		}{A: 4}, expected: "A: must be one of 1, 2, 3"},
+		"alpha": {value: struct {
+			A string `validate:"alpha"`
+		}{A: "abc1"}, expected: "A: must contain only letters"},
+		"alpha empty": {value: struct {
+			A string `validate:"alpha"`
+		}{}},
+		"chars": {value: struct {
+			A string `validate:"chars=ab"`
+		}{A: "abba"}},
+		"chars fails": {value: struct {
+			A string `validate:"chars=ab"`
+		}{A: "abc"}, expected: "A: must contain only the characters ab"},
+		"omitempty skips": {value: struct {
+			A string `validate:"omitempty,len=3"`
+		}{}},
+		"omitempty applies": {value: struct {
+			A string `validate:"omitempty,len=3"`
+		}{A: "ab"}, expected: "A: must be exactly 3 characters long"},
+		"dive into map values": {value: struct {
+			A map[string]int `validate:"dive,max=1"`
+		}{A: map[string]int{"b": 2, "a": 3}}, expected: "A[a]: must be at most 1; A[b]: must be at most 1"},
+		"dive twice": {value: struct {
+			A [][]string `validate:"dive,max=1,dive,required"`
+		}{A: [][]string{{"x"}, {""}}}, expected: "A[1][0]: is required"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := Validate(test.value)
+			if test.expected == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestCrossFieldRules(t *testing.T) {
+	type signup struct {
+		Password string    `json:"password" validate:"required"`
+		Confirm  string    `json:"confirm" validate:"eqfield=Password"`
+		Username string    `json:"username" validate:"nefield=Password"`
+		Min      int       `json:"min"`
+		Max      int       `json:"max" validate:"gtefield=Min"`
+		Start    time.Time `json:"start"`
+		End      time.Time `json:"end" validate:"gtfield=Start"`
+		Retries  int       `json:"retries" validate:"ltfield=Max"`
+		Limit    int       `json:"limit" validate:"ltefield=Max"`
+	}
+
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	valid := signup{Password: "secret", Confirm: "secret", Username: "bob", Min: 1, Max: 5, Start: start, End: start.Add(time.Hour), Retries: 4, Limit: 5}
+	assert.Nil(t, Validate(valid))
+
+	invalid := signup{Password: "secret", Confirm: "Secret", Username: "secret", Min: 6, Max: 5, Start: start, End: start, Retries: 5, Limit: 6}
+	assert.EqualError(t, Validate(invalid), "confirm: must equal password; "+
+		"username: must not equal password; "+
+		"max: must be greater than or equal to min; "+
+		"end: must be greater than start; "+
+		"retries: must be less than max; "+
+		"limit: must be less than or equal to max")
+}
+
+func TestCrossFieldRuleWithNilEmbeddedPointer(t *testing.T) {
+	type Limits struct {
+		Max int `json:"max"`
+	}
+	type request struct {
+		*Limits
+		Count int `json:"count" validate:"ltefield=Max"`
+	}
+
+	assert.EqualError(t, Validate(request{Count: 3}), "count: must be less than or equal to max")
+	assert.Nil(t, Validate(request{Limits: &Limits{Max: 5}, Count: 3}))
+}
+
+func TestCrossFieldRuleErrors(t *testing.T) {
+	var tests = map[string]struct {
+		value    any
+		expected string
+	}{
+		"missing field": {
+			value: struct {
+				A int `validate:"eqfield=B"`
+			}{},
+			expected: "invalid validate tag: the field B cannot be found in struct { A int \"validate:\\\"eqfield=B\\\"\" }",
+		},
+		"different types": {
+			value: struct {
+				A int `validate:"eqfield=B"`
+				B string
+			}{},
+			expected: "invalid validate tag: cannot compare int with the field B of type string",
+		},
+		"unordered type": {
+			value: struct {
+				A []int `validate:"eqfield=B"`
+				B []int
+			}{},
+			expected: "invalid validate tag: cannot compare []int with the field B of type []int",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.EqualError(t, Validate(test.value), test.expected)
+		})
+	}
+}
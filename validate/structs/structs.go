@@ -0,0 +1,354 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jwmajors81/golang-commons-lang/validate"
+)
+
+var (
+	// Returned when a validate tag is malformed or a rule cannot be applied
+	// to the type of its field.  Rules return it, wrapped with a description,
+	// to report a mistake in the tag rather than an invalid value.
+	ErrInvalidTag = errors.New("invalid validate tag")
+	// Returned when the value to validate is not a struct or a pointer to one
+	ErrNotStruct = errors.New("value is not a struct")
+	// The error of a failed required rule
+	ErrRequired = errors.New("value is required")
+)
+
+// Describes the field a rule is applied to
+type Field struct {
+	// The value of the field, or of an element when the rule follows dive
+	Value reflect.Value
+	// The text following the equals sign of the rule, empty when there is none
+	Param string
+	// The struct that contains the field, used by cross-field rules
+	Parent reflect.Value
+}
+
+// Checks a field, returning an error describing why the value is invalid or
+// nil when it is valid.  The message of the error is reported with the JSON
+// path of the field; an error wrapping ErrInvalidTag stops the validation and
+// is returned as is.
+type Rule func(field Field) error
+
+type ruleRef struct {
+	name  string
+	param string
+}
+
+type fieldInfo struct {
+	index int
+	// The JSON name of the field, empty for embedded structs whose fields
+	// are promoted
+	name  string
+	rules []ruleRef
+}
+
+// Validates structs according to the validate tags of their fields:
+//
+//	type User struct {
+//		Name   string    `json:"name" validate:"required,min=3,max=64,pattern=^[a-z]+$"`
+//		Emails []string  `json:"emails" validate:"min=1,dive,required"`
+//		Home   *Address  `json:"home"`
+//	}
+//
+// Rules are separated by commas and applied in order until one fails.  The
+// pattern rule takes the rest of the tag so that its expression may contain
+// commas.  Rules that follow dive are applied to each element of a slice,
+// array or map.  Nested structs, and structs in slices and maps, are always
+// validated, except that pointers, slices and maps that lead back to a value
+// already being validated are not followed.  Fields are reported by their
+// JSON path, such as "home.lines[1]" or "labels[team]".
+//
+// The built in rules are:
+//
+//	required       the value is not the zero value, or not empty for strings, slices and maps
+//	omitempty      skips the remaining rules when the value is the zero value
+//	min=n, max=n   bounds for numbers, or for the length of strings, slices and maps
+//	len=n          the exact length of a string, slice or map
+//	oneof=a b c    the value is one of the words
+//	pattern=re     the whole string matches the regular expression
+//	alpha          the string contains only ASCII letters
+//	numeric        the string contains only ASCII digits
+//	alphanum       the string contains only ASCII letters and digits
+//	chars=abc      the string contains only the characters listed
+//	excludes=<>    the string contains none of the characters listed
+//	eqfield=F      the value equals the field F of the same struct
+//	nefield=F      the value differs from the field F
+//	gtfield=F, gtefield=F, ltfield=F, ltefield=F
+//	               the value compares to the field F as described
+//	dive           applies the following rules to each element
+//
+// A Validator is safe for use by multiple goroutines.
+type Validator struct {
+	mu    sync.RWMutex
+	rules map[string]Rule
+	types sync.Map
+}
+
+// Creates a validator with the built in rules
+func NewValidator() *Validator {
+	v := &Validator{rules: map[string]Rule{}}
+	for name, rule := range builtinRules {
+		v.rules[name] = rule
+	}
+	return v
+}
+
+// Adds a rule that can be used in validate tags, replacing any rule with the
+// same name
+func (v *Validator) RegisterRule(name string, rule Rule) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.rules[name] = rule
+}
+
+func (v *Validator) rule(name string) (Rule, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	rule, ok := v.rules[name]
+	return rule, ok
+}
+
+var defaultValidator = NewValidator()
+
+// Validates the struct using the default validator
+func Validate(value any) error {
+	return defaultValidator.Validate(value)
+}
+
+// Adds a rule to the default validator
+func RegisterRule(name string, rule Rule) {
+	defaultValidator.RegisterRule(name, rule)
+}
+
+// Validates the struct, or pointer to a struct, provided.  Returns nil when
+// it is valid, a validate.Errors listing every field that is invalid, or an
+// error wrapping ErrNotStruct or ErrInvalidTag when the value cannot be
+// validated.
+func (v *Validator) Validate(value any) error {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T", ErrNotStruct, value)
+	}
+
+	collector := validate.NewValidator()
+	if err := v.validateValue(reflect.ValueOf(value), reflect.Value{}, nil, collector, map[visit]bool{}); err != nil {
+		return err
+	}
+	return collector.Err()
+}
+
+// Identifies a pointer, slice or map whose elements are being validated, so
+// that a value that refers to itself is not validated forever
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+func (v *Validator) validateStruct(rv reflect.Value, collector *validate.Validator, visiting map[visit]bool) error {
+	fields, err := v.fields(rv.Type())
+	if err != nil {
+		return err
+	}
+	for _, field := range fields {
+		if err := v.validateValue(rv.Field(field.index), rv, field.rules, collector.Field(field.name), visiting); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Applies the rules to the value and then validates its elements and nested
+// structs.  Only errors that stop the validation are returned, failed rules
+// are recorded with the collector.  Pointers, slices and maps that are
+// already being validated further up are skipped.
+func (v *Validator) validateValue(value reflect.Value, parent reflect.Value, rules []ruleRef, collector *validate.Validator, visiting map[visit]bool) error {
+	var elementRules []ruleRef
+	dive := false
+	for i, ref := range rules {
+		if ref.name == "dive" {
+			dive, elementRules = true, rules[i+1:]
+			break
+		}
+		if ref.name == "omitempty" {
+			if isEmpty(value) {
+				return nil
+			}
+			continue
+		}
+
+		rule, ok := v.rule(ref.name)
+		if !ok {
+			return fmt.Errorf("%w: unknown rule %q", ErrInvalidTag, ref.name)
+		}
+		if err := rule(Field{Value: value, Param: ref.param, Parent: parent}); err != nil {
+			if errors.Is(err, ErrInvalidTag) {
+				return err
+			}
+			collector.Check("", err)
+			return nil
+		}
+	}
+
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		if value.Kind() == reflect.Pointer {
+			key := visit{ptr: value.Pointer(), typ: value.Type()}
+			if visiting[key] {
+				return nil
+			}
+			visiting[key] = true
+			defer delete(visiting, key)
+		}
+		value = value.Elem()
+	}
+	if kind := value.Kind(); (kind == reflect.Slice || kind == reflect.Map) && !value.IsNil() {
+		key := visit{ptr: value.Pointer(), typ: value.Type()}
+		if visiting[key] {
+			return nil
+		}
+		visiting[key] = true
+		defer delete(visiting, key)
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		return v.validateStruct(value, collector, visiting)
+	case reflect.Slice, reflect.Array:
+		if !dive && !containsStructs(value.Type().Elem()) {
+			return nil
+		}
+		for i := 0; i < value.Len(); i++ {
+			if err := v.validateValue(value.Index(i), parent, elementRules, collector.Index("", i), visiting); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if !dive && !containsStructs(value.Type().Elem()) {
+			return nil
+		}
+		for _, entry := range sortedEntries(value) {
+			if err := v.validateValue(entry.value, parent, elementRules, collector.Key("", entry.key), visiting); err != nil {
+				return err
+			}
+		}
+	default:
+		if dive {
+			return fmt.Errorf("%w: dive cannot be used with %s", ErrInvalidTag, value.Type())
+		}
+	}
+	return nil
+}
+
+func containsStructs(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct || t.Kind() == reflect.Interface
+}
+
+type mapEntry struct {
+	key   string
+	value reflect.Value
+}
+
+// Returns the entries of the map sorted by the text of their keys, so that
+// failures are reported in the same order every time
+func sortedEntries(m reflect.Value) []mapEntry {
+	entries := make([]mapEntry, 0, m.Len())
+	iter := m.MapRange()
+	for iter.Next() {
+		entries = append(entries, mapEntry{key: fmt.Sprint(iter.Key().Interface()), value: iter.Value()})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+	return entries
+}
+
+// Returns the exported fields of the struct type with their parsed rules
+func (v *Validator) fields(t reflect.Type) ([]fieldInfo, error) {
+	if cached, ok := v.types.Load(t); ok {
+		return cached.([]fieldInfo), nil
+	}
+
+	var fields []fieldInfo
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		rules, err := parseTag(field.Tag.Get("validate"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: field %s of %s", err, field.Name, t)
+		}
+		fields = append(fields, fieldInfo{index: i, name: jsonName(field), rules: rules})
+	}
+
+	v.types.Store(t, fields)
+	return fields, nil
+}
+
+// Returns the name of the field in JSON, or an empty name for an embedded
+// struct whose fields encoding/json promotes
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		name = ""
+	}
+	if name != "" {
+		return name
+	}
+	if field.Anonymous {
+		t := field.Type
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Struct {
+			return ""
+		}
+	}
+	return field.Name
+}
+
+// Splits a validate tag into rules.  The pattern rule takes the rest of the tag.
+func parseTag(tag string) ([]ruleRef, error) {
+	var rules []ruleRef
+	for tag != "" {
+		var part string
+		if strings.HasPrefix(tag, "pattern=") {
+			part, tag = tag, ""
+		} else {
+			part, tag, _ = strings.Cut(tag, ",")
+		}
+
+		name, param, _ := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: empty rule", ErrInvalidTag)
+		}
+		rules = append(rules, ruleRef{name: name, param: param})
+	}
+	return rules, nil
+}
+
+func isEmpty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return value.Len() == 0
+	}
+	return !value.IsValid() || value.IsZero()
+}
@@ -0,0 +1,230 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jwmajors81/golang-commons-lang/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+type Address struct {
+	Street string   `json:"street" validate:"required"`
+	Zip    string   `json:"zip" validate:"numeric,len=5"`
+	Lines  []string `json:"lines,omitempty" validate:"max=3,dive,required,max=20"`
+}
+
+type Audit struct {
+	CreatedBy string `json:"createdBy" validate:"required"`
+}
+
+type User struct {
+	Audit
+	Name     string            `json:"name" validate:"required,min=3,max=64,pattern=^[a-z]{1,64}$"`
+	Age      int               `json:"age" validate:"min=18,max=150"`
+	Role     string            `json:"role" validate:"oneof=admin user guest"`
+	Home     *Address          `json:"home"`
+	Offices  []Address         `json:"offices"`
+	Labels   map[string]string `json:"labels" validate:"dive,alphanum"`
+	Nickname string            `json:"nickname,omitempty" validate:"omitempty,min=2"`
+	Internal string            `json:"-" validate:"excludes=<>"`
+	secret   string
+}
+
+func validUser() User {
+	return User{
+		Audit:   Audit{CreatedBy: "system"},
+		Name:    "alice",
+		Age:     30,
+		Role:    "admin",
+		Home:    &Address{Street: "1 Main St", Zip: "12345"},
+		Offices: []Address{{Street: "2 Side St", Zip: "54321"}},
+		Labels:  map[string]string{"team": "core"},
+	}
+}
+
+func TestValidateValidStruct(t *testing.T) {
+	user := validUser()
+	assert.Nil(t, Validate(user))
+	assert.Nil(t, Validate(&user))
+}
+
+func TestValidateReportsEveryViolation(t *testing.T) {
+	user := validUser()
+	user.CreatedBy = ""
+	user.Name = "Al"
+	user.Age = 12
+	user.Role = "root"
+	user.Home.Zip = "12a45"
+	user.Home.Lines = []string{"ok", "", strings.Repeat("x", 21)}
+	user.Offices = append(user.Offices, Address{Zip: "123"})
+	user.Labels["cost-center"] = "a-b"
+	user.Labels["zone"] = "eu west"
+	user.Nickname = "x"
+	user.Internal = "<b>"
+
+	err := Validate(user)
+
+	var errs validate.Errors
+	assert.True(t, errors.As(err, &errs))
+	assert.Equal(t, []string{
+		"createdBy: is required",
+		"name: must be at least 3 characters long",
+		"age: must be at least 18",
+		"role: must be one of admin, user, guest",
+		"home.zip: must contain only digits",
+		"home.lines[1]: is required",
+		"home.lines[2]: must be at most 20 characters long",
+		"offices[1].street: is required",
+		"offices[1].zip: must be exactly 5 characters long",
+		"labels[cost-center]: must contain only letters and digits",
+		"labels[zone]: must contain only letters and digits",
+		"nickname: must be at least 2 characters long",
+		"Internal: must not contain any of the characters <>",
+	}, messages(errs))
+
+	assert.ErrorIs(t, err, ErrRequired)
+	assert.ErrorIs(t, err, validate.ErrNotInRange)
+	assert.ErrorIs(t, err, validate.ErrPatternMismatch)
+	assert.Len(t, errs.ForField("home"), 3)
+}
+
+func messages(errs validate.Errors) []string {
+	var result []string
+	for _, err := range errs {
+		result = append(result, err.Error())
+	}
+	return result
+}
+
+func TestValidateStopsAtFirstFailedRuleOfAField(t *testing.T) {
+	user := validUser()
+	user.Name = ""
+	err := Validate(user)
+	assert.EqualError(t, err, "name: is required")
+}
+
+func TestValidateInvalidInput(t *testing.T) {
+	var tests = map[string]struct {
+		value         any
+		expectedError string
+	}{
+		"not a struct": {value: 42, expectedError: "value is not a struct: int"},
+		"nil pointer":  {value: (*User)(nil), expectedError: "value is not a struct: *structs.User"},
+		"nil":          {value: nil, expectedError: "value is not a struct: <nil>"},
+		"unknown rule": {value: struct {
+			A string `validate:"required,shiny"`
+		}{A: "a"}, expectedError: `invalid validate tag: unknown rule "shiny"`},
+		"empty rule": {value: struct {
+			A string `validate:"required,,min=1"`
+		}{}, expectedError: "invalid validate tag: empty rule: field A of struct { A string \"validate:\\\"required,,min=1\\\"\" }"},
+		"bad number": {value: struct {
+			A int `validate:"min=ten"`
+		}{}, expectedError: `invalid validate tag: "ten" is not a number`},
+		"bad pattern": {value: struct {
+			A string `validate:"pattern=[a-"`
+		}{}, expectedError: `invalid validate tag: "[a-" is not a valid regular expression`},
+		"wrong type": {value: struct {
+			A bool `validate:"min=1"`
+		}{}, expectedError: "invalid validate tag: cannot compare the size of bool"},
+		"dive on value": {value: struct {
+			A int `validate:"dive,min=1"`
+		}{}, expectedError: "invalid validate tag: dive cannot be used with int"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := Validate(test.value)
+			assert.EqualError(t, err, test.expectedError)
+
+			var errs validate.Errors
+			assert.False(t, errors.As(err, &errs))
+		})
+	}
+}
+
+func TestPatternMayContainCommas(t *testing.T) {
+	type code struct {
+		Value string `json:"value" validate:"required,pattern=^[A-Z]{2,3}-[0-9]{1,4}$"`
+	}
+
+	assert.Nil(t, Validate(code{Value: "AB-12"}))
+	assert.EqualError(t, Validate(code{Value: "A-12"}), `value: must match the pattern ^[A-Z]{2,3}-[0-9]{1,4}$`)
+}
+
+func TestNestedPointersAndInterfaces(t *testing.T) {
+	type node struct {
+		Name     string  `json:"name" validate:"required"`
+		Children []*node `json:"children"`
+		Extra    any     `json:"extra"`
+	}
+
+	tree := node{Name: "root", Children: []*node{{Name: "a"}, nil, {Children: []*node{{}}}}, Extra: &Address{Zip: "1"}}
+	err := Validate(tree)
+	assert.EqualError(t, err, "children[2].name: is required; "+
+		"children[2].children[0].name: is required; "+
+		"extra.street: is required; "+
+		"extra.zip: must be exactly 5 characters long")
+}
+
+func TestCyclicValues(t *testing.T) {
+	type node struct {
+		Name  string         `json:"name" validate:"required"`
+		Next  *node          `json:"next"`
+		Links map[string]any `json:"links"`
+	}
+
+	self := &node{}
+	self.Next = self
+	assert.EqualError(t, Validate(self), "name: is required")
+
+	first := &node{Name: "first"}
+	second := &node{Next: first}
+	first.Next = second
+	assert.EqualError(t, Validate(first), "next.name: is required")
+
+	links := map[string]any{}
+	links["self"] = links
+	assert.Nil(t, Validate(node{Name: "root", Links: links}))
+}
+
+func TestCustomRules(t *testing.T) {
+	v := NewValidator()
+	v.RegisterRule("even", func(field Field) error {
+		if field.Value.Kind() != reflect.Int {
+			return fmt.Errorf("%w: even cannot be used with %s", ErrInvalidTag, field.Value.Type())
+		}
+		if field.Value.Int()%2 != 0 {
+			return errors.New("must be even")
+		}
+		return nil
+	})
+	v.RegisterRule("prefix", func(field Field) error {
+		if !strings.HasPrefix(field.Value.String(), field.Param) {
+			return &validate.Error{Message: "must start with " + field.Param, Err: validate.ErrPatternMismatch}
+		}
+		return nil
+	})
+
+	type order struct {
+		Quantity int    `json:"quantity" validate:"min=2,even"`
+		ID       string `json:"id" validate:"prefix=ord-"`
+	}
+
+	assert.Nil(t, v.Validate(order{Quantity: 4, ID: "ord-1"}))
+
+	err := v.Validate(order{Quantity: 3, ID: "1"})
+	assert.EqualError(t, err, "quantity: must be even; id: must start with ord-")
+	assert.ErrorIs(t, err, validate.ErrPatternMismatch)
+
+	// rules registered with one validator are not known to others
+	assert.ErrorIs(t, Validate(order{Quantity: 4, ID: "ord-1"}), ErrInvalidTag)
+
+	type broken struct {
+		Name string `validate:"even"`
+	}
+	assert.EqualError(t, v.Validate(broken{}), "invalid validate tag: even cannot be used with string")
+}
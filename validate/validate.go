@@ -0,0 +1,190 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+var (
+	// Returned by IsTrue when the expression is false
+	ErrIllegalArgument = errors.New("illegal argument")
+	// Returned by NotNil when the value is nil
+	ErrNil = errors.New("value is nil")
+	// Returned by NotBlank when the string is empty or only whitespace
+	ErrBlank = errors.New("value is blank")
+	// Returned by NotEmpty when the value has no elements
+	ErrEmpty = errors.New("value is empty")
+	// Returned by InclusiveBetween and ExclusiveBetween when the value is outside the range
+	ErrNotInRange = errors.New("value is not in range")
+	// Returned by MatchesPattern when the string does not match
+	ErrPatternMismatch = errors.New("value does not match pattern")
+	// Returned by ValidIndex when the index is outside the slice
+	ErrInvalidIndex = errors.New("index is invalid")
+	// Returned by NoNilElements when an element is nil
+	ErrNilElement = errors.New("value contains a nil element")
+)
+
+// A failed validation.  The message describes the failure and errors.Is
+// matches the sentinel error of the check that failed, such as ErrBlank.
+type Error struct {
+	// The path of the field that failed, such as "address.lines[1]", empty
+	// unless the error was recorded by a Validator
+	Field string
+	// The description of the failure, without the field
+	Message string
+	// The sentinel error of the check, or the error recorded by a Validator
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Creates an Error for the sentinel.  The message is the first of msgAndArgs
+// formatted with the rest, or the default message when msgAndArgs is empty.
+func newError(sentinel error, msgAndArgs []any, defaultFormat string, defaultArgs ...any) *Error {
+	return &Error{Message: message(msgAndArgs, defaultFormat, defaultArgs), Err: sentinel}
+}
+
+func message(msgAndArgs []any, defaultFormat string, defaultArgs []any) string {
+	if len(msgAndArgs) == 0 {
+		return fmt.Sprintf(defaultFormat, defaultArgs...)
+	}
+	format, ok := msgAndArgs[0].(string)
+	if !ok {
+		return fmt.Sprint(msgAndArgs...)
+	}
+	if len(msgAndArgs) == 1 {
+		return format
+	}
+	return fmt.Sprintf(format, msgAndArgs[1:]...)
+}
+
+// Returns an error wrapping ErrIllegalArgument when the expression is false.
+// The optional msgAndArgs are a message format followed by its arguments.
+//
+//	validate.IsTrue(width > 3, "the width must be greater than 3 but was %d", width)
+func IsTrue(expression bool, msgAndArgs ...any) error {
+	if expression {
+		return nil
+	}
+	return newError(ErrIllegalArgument, msgAndArgs, "the validated expression is false")
+}
+
+// Returns an error wrapping ErrNil when the value is nil or a nil pointer,
+// slice, map, channel, function or interface
+func NotNil(value any, msgAndArgs ...any) error {
+	if !isNil(value) {
+		return nil
+	}
+	return newError(ErrNil, msgAndArgs, "the validated object is nil")
+}
+
+// Returns an error wrapping ErrBlank when the string is empty or only whitespace
+func NotBlank(value string, msgAndArgs ...any) error {
+	if strings.TrimSpace(value) != "" {
+		return nil
+	}
+	return newError(ErrBlank, msgAndArgs, "the validated string is blank")
+}
+
+// Returns an error wrapping ErrEmpty when the value is nil or an empty
+// string, slice, array, map or channel.  Other values are never empty.
+func NotEmpty(value any, msgAndArgs ...any) error {
+	if !isEmpty(value) {
+		return nil
+	}
+	return newError(ErrEmpty, msgAndArgs, "the validated value is empty")
+}
+
+// Returns an error wrapping ErrNotInRange when the value is less than start or greater than end
+func InclusiveBetween[T constraints.Ordered](start T, end T, value T, msgAndArgs ...any) error {
+	if value >= start && value <= end {
+		return nil
+	}
+	return newError(ErrNotInRange, msgAndArgs, "the value %v is not in the specified inclusive range of %v to %v", value, start, end)
+}
+
+// Returns an error wrapping ErrNotInRange when the value is not greater than start and less than end
+func ExclusiveBetween[T constraints.Ordered](start T, end T, value T, msgAndArgs ...any) error {
+	if value > start && value < end {
+		return nil
+	}
+	return newError(ErrNotInRange, msgAndArgs, "the value %v is not in the specified exclusive range of %v to %v", value, start, end)
+}
+
+// Returns an error wrapping ErrPatternMismatch when the whole string does not
+// match the regular expression.  An invalid pattern is returned as an error
+// from the regexp package.
+func MatchesPattern(value string, pattern string, msgAndArgs ...any) error {
+	// The pattern is not wrapped in anchors, which would change the compile
+	// errors and let text such as "a)|(b" escape them.  In leftmost-longest
+	// mode the first match is the whole string whenever the whole string
+	// matches.
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	re.Longest()
+	if match := re.FindStringIndex(value); match != nil && match[0] == 0 && match[1] == len(value) {
+		return nil
+	}
+	return newError(ErrPatternMismatch, msgAndArgs, "the string %s does not match the pattern %s", value, pattern)
+}
+
+// Returns an error wrapping ErrInvalidIndex when the index is outside the slice
+func ValidIndex[T any](values []T, index int, msgAndArgs ...any) error {
+	if index >= 0 && index < len(values) {
+		return nil
+	}
+	return newError(ErrInvalidIndex, msgAndArgs, "the validated slice index is invalid: %d", index)
+}
+
+// Returns an error wrapping ErrNilElement for the first element of the
+// slice that is nil
+func NoNilElements[T any](values []T, msgAndArgs ...any) error {
+	for i, value := range values {
+		if isNil(value) {
+			return newError(ErrNilElement, msgAndArgs, "the validated slice contains nil element at index: %d", i)
+		}
+	}
+	return nil
+}
+
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+func isEmpty(value any) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
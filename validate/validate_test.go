@@ -0,0 +1,79 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecks(t *testing.T) {
+	var nilPointer *int
+	one := 1
+
+	var tests = map[string]struct {
+		err             error
+		expectedMessage string
+		expectedKind    error
+	}{
+		"is true":                        {err: IsTrue(true)},
+		"is false":                       {err: IsTrue(false), expectedMessage: "the validated expression is false", expectedKind: ErrIllegalArgument},
+		"is false with message":          {err: IsTrue(false, "width must be at least %d but was %d", 4, 2), expectedMessage: "width must be at least 4 but was 2", expectedKind: ErrIllegalArgument},
+		"message without arguments":      {err: IsTrue(false, "100% wrong"), expectedMessage: "100% wrong", expectedKind: ErrIllegalArgument},
+		"message that is not a string":   {err: IsTrue(false, 42), expectedMessage: "42", expectedKind: ErrIllegalArgument},
+		"not nil":                        {err: NotNil(&one)},
+		"nil":                            {err: NotNil(nil), expectedMessage: "the validated object is nil", expectedKind: ErrNil},
+		"nil pointer":                    {err: NotNil(nilPointer), expectedMessage: "the validated object is nil", expectedKind: ErrNil},
+		"not blank":                      {err: NotBlank(" a ")},
+		"blank":                          {err: NotBlank(" \t\n"), expectedMessage: "the validated string is blank", expectedKind: ErrBlank},
+		"empty string is blank":          {err: NotBlank("", "name is required"), expectedMessage: "name is required", expectedKind: ErrBlank},
+		"not empty string":               {err: NotEmpty(" ")},
+		"not empty slice":                {err: NotEmpty([]int{1})},
+		"not empty number":               {err: NotEmpty(0)},
+		"empty string":                   {err: NotEmpty(""), expectedMessage: "the validated value is empty", expectedKind: ErrEmpty},
+		"empty slice":                    {err: NotEmpty([]string{}), expectedMessage: "the validated value is empty", expectedKind: ErrEmpty},
+		"nil map":                        {err: NotEmpty(map[string]int(nil)), expectedMessage: "the validated value is empty", expectedKind: ErrEmpty},
+		"nil value":                      {err: NotEmpty(nil), expectedMessage: "the validated value is empty", expectedKind: ErrEmpty},
+		"inclusive between":              {err: InclusiveBetween(1, 10, 10)},
+		"inclusive below":                {err: InclusiveBetween(1, 10, 0), expectedMessage: "the value 0 is not in the specified inclusive range of 1 to 10", expectedKind: ErrNotInRange},
+		"inclusive strings":              {err: InclusiveBetween("b", "d", "e"), expectedMessage: "the value e is not in the specified inclusive range of b to d", expectedKind: ErrNotInRange},
+		"exclusive between":              {err: ExclusiveBetween(1.0, 2.0, 1.5)},
+		"exclusive at the end":           {err: ExclusiveBetween(1.0, 2.0, 2.0), expectedMessage: "the value 2 is not in the specified exclusive range of 1 to 2", expectedKind: ErrNotInRange},
+		"matches pattern":                {err: MatchesPattern("abc123", `[a-z]+\d+`)},
+		"matches only part of pattern":   {err: MatchesPattern("abc123!", `[a-z]+\d+`), expectedMessage: `the string abc123! does not match the pattern [a-z]+\d+`, expectedKind: ErrPatternMismatch},
+		"alternation is anchored":        {err: MatchesPattern("ab", `a|b`), expectedMessage: "the string ab does not match the pattern a|b", expectedKind: ErrPatternMismatch},
+		"longer alternative matches":     {err: MatchesPattern("ab", `a|ab`)},
+		"pattern matching empty string":  {err: MatchesPattern("", `a*`)},
+		"valid index":                    {err: ValidIndex([]int{1, 2}, 1)},
+		"index too large":                {err: ValidIndex([]int{1, 2}, 2), expectedMessage: "the validated slice index is invalid: 2", expectedKind: ErrInvalidIndex},
+		"negative index":                 {err: ValidIndex([]int{1, 2}, -1, "no item %d", -1), expectedMessage: "no item -1", expectedKind: ErrInvalidIndex},
+		"no nil elements":                {err: NoNilElements([]*int{&one})},
+		"no nil elements of non-nilable": {err: NoNilElements([]int{0, 0})},
+		"nil element":                    {err: NoNilElements([]any{1, "a", nil}), expectedMessage: "the validated slice contains nil element at index: 2", expectedKind: ErrNilElement},
+		"nil pointer element":            {err: NoNilElements([]*int{&one, nilPointer}), expectedMessage: "the validated slice contains nil element at index: 1", expectedKind: ErrNilElement},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if test.expectedKind == nil {
+				assert.Nil(t, test.err)
+				return
+			}
+			assert.EqualError(t, test.err, test.expectedMessage)
+			assert.ErrorIs(t, test.err, test.expectedKind)
+
+			var validationErr *Error
+			assert.True(t, errors.As(test.err, &validationErr))
+			assert.Equal(t, "", validationErr.Field)
+		})
+	}
+}
+
+func TestMatchesPatternWithInvalidPattern(t *testing.T) {
+	err := MatchesPattern("abc", "[a-")
+	assert.EqualError(t, err, "error parsing regexp: missing closing ]: `[a-`")
+	assert.False(t, errors.Is(err, ErrPatternMismatch))
+
+	err = MatchesPattern("ab", "a)|(b")
+	assert.EqualError(t, err, "error parsing regexp: unexpected ): `a)|(b`")
+}
@@ -0,0 +1,152 @@
+package validate
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// The failures recorded by a Validator
+type Errors []*Error
+
+// Returns the failures separated by semicolons
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Returns the failures so that errors.Is and errors.As examine each of them
+func (e Errors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Reports whether any of the failures matches the target
+func (e Errors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Finds the first of the failures that matches the target
+func (e Errors) As(target any) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the failures of the field, including those of its nested fields
+func (e Errors) ForField(field string) Errors {
+	var matching Errors
+	for _, err := range e {
+		if err.Field == field || strings.HasPrefix(err.Field, field+".") || strings.HasPrefix(err.Field, field+"[") {
+			matching = append(matching, err)
+		}
+	}
+	return matching
+}
+
+// Collects every failed check rather than stopping at the first, recording
+// the path of the field that failed.
+//
+//	v := validate.NewValidator()
+//	v.Check("name", validate.NotBlank(user.Name))
+//	address := v.Field("address")
+//	address.Check("zip", validate.MatchesPattern(user.Address.Zip, `\d{5}`))
+//	for i, line := range user.Address.Lines {
+//		address.Index("lines", i).Check("", validate.NotBlank(line))
+//	}
+//	return v.Err()
+//
+// A Validator is not safe for use by multiple goroutines.
+type Validator struct {
+	path   string
+	errors *Errors
+}
+
+// Creates a Validator without any failures
+func NewValidator() *Validator {
+	return &Validator{errors: &Errors{}}
+}
+
+// Records the error, if any, as a failure of the field.  The field is
+// relative to the path of the validator and may be empty to record a
+// failure of the path itself.  Returns the validator so that checks can be
+// chained.
+func (v *Validator) Check(field string, err error) *Validator {
+	if err == nil {
+		return v
+	}
+
+	path := join(v.path, field)
+	var validationErr *Error
+	if errors.As(err, &validationErr) && validationErr.Field == "" {
+		*v.errors = append(*v.errors, &Error{Field: path, Message: validationErr.Message, Err: validationErr.Err})
+	} else {
+		*v.errors = append(*v.errors, &Error{Field: path, Message: err.Error(), Err: err})
+	}
+	return v
+}
+
+// Records a failure of the field with the message when the expression is false
+func (v *Validator) Require(field string, expression bool, msgAndArgs ...any) *Validator {
+	return v.Check(field, IsTrue(expression, msgAndArgs...))
+}
+
+// Returns a validator for a nested field that records its failures with
+// this validator
+func (v *Validator) Field(name string) *Validator {
+	return &Validator{path: join(v.path, name), errors: v.errors}
+}
+
+// Returns a validator for an element of a nested slice field, whose path is
+// the field followed by the index, such as "lines[2]"
+func (v *Validator) Index(name string, index int) *Validator {
+	return &Validator{path: join(v.path, name) + "[" + strconv.Itoa(index) + "]", errors: v.errors}
+}
+
+// Returns a validator for the value of a nested map field, whose path is the
+// field followed by the key, such as "labels[app]"
+func (v *Validator) Key(name string, key string) *Validator {
+	return &Validator{path: join(v.path, name) + "[" + key + "]", errors: v.errors}
+}
+
+// Returns whether no failures have been recorded
+func (v *Validator) Valid() bool {
+	return len(*v.errors) == 0
+}
+
+// Returns the failures recorded so far, including those of nested validators
+func (v *Validator) Errors() Errors {
+	return append(Errors(nil), *v.errors...)
+}
+
+// Returns the failures as an Errors, or nil when there are none
+func (v *Validator) Err() error {
+	if v.Valid() {
+		return nil
+	}
+	return v.Errors()
+}
+
+func join(path string, field string) string {
+	switch {
+	case path == "":
+		return field
+	case field == "":
+		return path
+	}
+	return path + "." + field
+}
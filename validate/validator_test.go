@@ -0,0 +1,101 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	Zip   string
+	Lines []string
+}
+
+type user struct {
+	Name    string
+	Age     int
+	Address address
+	Labels  map[string]string
+}
+
+func validateUser(u user) error {
+	v := NewValidator()
+	v.Check("name", NotBlank(u.Name)).
+		Check("age", InclusiveBetween(0, 150, u.Age))
+
+	a := v.Field("address")
+	a.Check("zip", MatchesPattern(u.Address.Zip, `\d{5}`))
+	for i, line := range u.Address.Lines {
+		a.Index("lines", i).Check("", NotBlank(line, "the line must not be blank"))
+	}
+	for key, value := range u.Labels {
+		v.Key("labels", key).Require("", len(value) <= 5, "the label is longer than %d characters", 5)
+	}
+	return v.Err()
+}
+
+func TestValidator(t *testing.T) {
+	valid := user{Name: "Ann", Age: 30, Address: address{Zip: "12345", Lines: []string{"1 Main St"}}}
+	assert.Nil(t, validateUser(valid))
+
+	invalid := user{Age: 200, Address: address{Zip: "1234", Lines: []string{"1 Main St", " "}}, Labels: map[string]string{"team": "platform"}}
+	err := validateUser(invalid)
+
+	var errs Errors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 5)
+	assert.Equal(t, "name: the validated string is blank; "+
+		"age: the value 200 is not in the specified inclusive range of 0 to 150; "+
+		"address.zip: the string 1234 does not match the pattern \\d{5}; "+
+		"address.lines[1]: the line must not be blank; "+
+		"labels[team]: the label is longer than 5 characters", err.Error())
+
+	assert.ErrorIs(t, err, ErrBlank)
+	assert.ErrorIs(t, err, ErrNotInRange)
+	assert.ErrorIs(t, err, ErrIllegalArgument)
+	assert.False(t, errors.Is(err, ErrNilElement))
+
+	assert.Equal(t, []string{"address.zip", "address.lines[1]"}, fields(errs.ForField("address")))
+	assert.Equal(t, []string{"address.lines[1]"}, fields(errs.ForField("address.lines")))
+	assert.Empty(t, errs.ForField("addr"))
+
+	var first *Error
+	assert.True(t, errors.As(err, &first))
+	assert.Equal(t, "name", first.Field)
+	assert.Equal(t, "the validated string is blank", first.Message)
+}
+
+func fields(errs Errors) []string {
+	var result []string
+	for _, err := range errs {
+		result = append(result, err.Field)
+	}
+	return result
+}
+
+func TestValidatorWithOtherErrors(t *testing.T) {
+	errTaken := errors.New("the name is already taken")
+	v := NewValidator()
+	v.Check("name", errTaken)
+
+	assert.False(t, v.Valid())
+	assert.EqualError(t, v.Err(), "name: the name is already taken")
+	assert.ErrorIs(t, v.Err(), errTaken)
+}
+
+func TestValidatorKeepsFieldOfNestedValidation(t *testing.T) {
+	nested := NewValidator().Check("street", NotBlank("")).Err()
+
+	v := NewValidator()
+	v.Check("address", nested)
+	assert.EqualError(t, v.Err(), "address: street: the validated string is blank")
+}
+
+func TestValidatorWithoutFailures(t *testing.T) {
+	v := NewValidator()
+	v.Check("name", nil).Require("age", true)
+	assert.True(t, v.Valid())
+	assert.Nil(t, v.Err())
+	assert.Empty(t, v.Errors())
+}